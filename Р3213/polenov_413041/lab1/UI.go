@@ -3,25 +3,38 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/cmplx"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
+
+	"newOne/linalg"
 )
 
 func CallUI() {
 	fmt.Println("How do you want to enter the matrix?\nType \"f\" for file or \"k\" for keyboard" +
-		" or \"r\" to generate random matrix and write it to file")
+		" or \"r\" to generate random matrix and write it to file (\"b\" - LU factorization benchmark," +
+		" \"v\" - eigenvalues of a square matrix from file without right side)")
 	var ans string
 	fmt.Fscanln(os.Stdin, &ans)
 
 	var matrix [][]float64
+	// число неизвестных, для переопределенных и недоопределенных систем
+	// не совпадает с числом строк
+	var n int
+	// путь к файлу с матрицей, пустой при вводе с клавиатуры
+	var path string
 
 	switch ans {
 	case "f":
 		var err error
-		var path string
 
 		path, err = readFilePath()
 		if err != nil {
@@ -29,7 +42,21 @@ func CallUI() {
 			os.Exit(1)
 		}
 
-		matrix, err = ReadMatrixFromFile(path)
+		if linalg.IsBandFile(path) {
+			solveBanded(path)
+			return
+		}
+		if strings.HasSuffix(path, ".mtx") {
+			solveMatrixMarket(path)
+			return
+		}
+
+		if linalg.IsComplexFile(path) {
+			solveComplexSystem(path)
+			return
+		}
+
+		matrix, n, err = linalg.ReadMatrixFromFile(path)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -42,19 +69,39 @@ func CallUI() {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		n = len(matrix)
 	case "r":
-		//var err error
-		path, err := GenerateRandomMatrixFile()
+		var err error
+
+		path, err = GenerateRandomMatrixFile()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		matrix, err = ReadMatrixFromFile(path)
+		matrix, n, err = linalg.ReadMatrixFromFile(path)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 
+	case "b":
+		runBenchmark()
+		return
+
+	case "v":
+		path, err := readFilePath()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		a, err := linalg.ReadSquareMatrixFromFile(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		solveEigen(a)
+		return
+
 	default:
 		fmt.Println("idk what it means :(")
 		os.Exit(1)
@@ -63,16 +110,991 @@ func CallUI() {
 	//fmt.Println(matrix)
 	//os.Exit(1)
 
+	// точное решение системы, если оно известно (матрица сгенерирована
+	// по решению x), используется для вывода погрешности, а не только невязки
+	var known []float64
+	if path != "" {
+		var err error
+		known, err = readKnownSolution(path, n, len(matrix[0])-n)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if len(matrix) != n {
+		solveLeastSquares(matrix, n, known)
+		return
+	}
+
+	fmt.Println("Choose method: \"g\" - Gauss elimination, \"e\" - exact Gauss elimination in fractions," +
+		" \"l\" - LU factorization, \"c\" - Cholesky/LDLᵀ for symmetric matrices, \"j\" - Jacobi, \"s\" - Gauss-Seidel," +
+		" \"o\" - successive over-relaxation (SOR), \"sp\" - sparse solvers (Gauss-Seidel, CG, BiCGSTAB, GMRES)" +
+		" or \"v\" - eigenvalues of the coefficient matrix")
+	var method string
+	if _, err := fmt.Fscan(os.Stdin, &method); err != nil {
+		fmt.Println("error reading method:", err)
+		os.Exit(1)
+	}
+
+	switch method {
+	case "g":
+		solveGauss(matrix, known)
+	case "e":
+		solveExact(matrix, path)
+	case "l":
+		solveLU(matrix, known)
+	case "c":
+		solveSymmetric(matrix, known)
+	case "j", "s", "o":
+		solveIterative(matrix, method, known)
+	case "sp":
+		a, b := linalg.SplitAugmented(matrix)
+		solveSparse(linalg.DenseToCSR(a), b, known)
+	case "v":
+		a, _ := linalg.SplitAugmented(matrix)
+		solveEigen(a)
+	default:
+		fmt.Println("unknown method:", method)
+		os.Exit(1)
+	}
+}
+
+// solveGauss решение методом Гаусса с выбором главного элемента
+func solveGauss(matrix linalg.Matrix, known []float64) {
+	a, b := linalg.SplitAugmented(matrix)
+	if linalg.IsSymmetric(a) {
+		fmt.Println("Матрица симметрична: разложение Холецкого или LDLᵀ (метод \"c\") требует вдвое меньше операций")
+	}
+
+	pivot, err := readPivotStrategy()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	trace, traceFormat, err := readTraceFormat()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	// Вычисление и вывод определителя
-	det, m := Determinant(matrix)
-	fmt.Println("Определитель матрицы:", det, "\nМатрица: ", m)
+	g, err := linalg.NewGaussElimination(matrix, pivot, trace)
+	det := 0.0
+	if err == nil {
+		det = g.Determinant()
+	}
+	fmt.Println("Определитель матрицы:", det, "\nВыбор главного элемента:", pivot)
 
-	// обратный ход метода гаусса
-	x := GaussSolverBackward(m)
-	fmt.Println("Решения: ", x)
+	printExactDeterminant(a, det)
+	if err != nil || linalg.IsRankDeficient(a) {
+		solveSingular(a, b, trace)
+		exportTrace(trace, traceFormat)
+		return
+	}
+	if n := len(g.M); n <= linalg.MaxPrintDimension {
+		fmt.Println("Матрица: ", g.M)
+	}
+	printConditioning(a)
+
+	refine, err := readRefinement()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for c := range b {
+		if refine {
+			// преобразования прямого хода повторяются для невязки
+			printRefinedSolution(c, a, b[c], g, known)
+			continue
+		}
+		// обратный ход метода гаусса
+		x := linalg.GaussSolverBackward(g.M, g.Order, c)
+		printSolution(c, x, linalg.CalculateDeltas(linalg.Augment(a, b[c]), x), known)
+	}
+	exportTrace(trace, traceFormat)
+}
+
+// readTraceFormat спрашивает, нужно ли сохранить трассу преобразований.
+// Возвращает nil, если трасса не нужна
+func readTraceFormat() (*linalg.EliminationTrace, string, error) {
+	fmt.Println("Export elimination trace? Type \"n\" - no, \"text\" - plain text," +
+		" \"md\" - Markdown tables or \"tex\" - LaTeX pmatrix blocks")
+	var format string
+	if _, err := fmt.Fscan(os.Stdin, &format); err != nil {
+		return nil, "", fmt.Errorf("error reading trace format: %v", err)
+	}
+	switch format {
+	case "n":
+		return nil, format, nil
+	case "text", "md", "tex":
+		return &linalg.EliminationTrace{}, format, nil
+	default:
+		return nil, "", fmt.Errorf("unknown trace format: %v", format)
+	}
+}
+
+// exportTrace сохраняет трассу в файл trace.txt, trace.md или trace.tex
+func exportTrace(trace *linalg.EliminationTrace, format string) {
+	if trace == nil {
+		return
+	}
+	path := traceFileName(format)
+	if err := trace.Export(path, format); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Трасса преобразований (%d шагов) сохранена в файл %s\n", len(trace.Steps), path)
+}
+
+// traceFileName имя файла трассы по умолчанию для формата "text", "md" или "tex"
+func traceFileName(format string) string {
+	return "trace." + map[string]string{"text": "txt", "md": "md", "tex": "tex"}[format]
+}
+
+// solveExact решение методом Гаусса в рациональных числах без ошибок округления.
+// Числа из файла переводятся в дроби по их десятичной записи
+func solveExact(matrix linalg.Matrix, path string) {
+	var exact [][]*big.Rat
+	if path != "" {
+		var err error
+		exact, err = linalg.ReadRatMatrixFromFile(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	} else {
+		exact = linalg.ToRatMatrix(matrix)
+	}
+
+	det, m := linalg.DeterminantRat(exact)
+	fmt.Println("Определитель матрицы:", formatRat(det))
+
+	a, b := linalg.SplitAugmented(matrix)
+	if m == nil {
+		solveSingular(a, b, nil)
+		return
+	}
+
+	n := len(exact)
+	for c := range b {
+		x := linalg.GaussSolverBackwardRat(m, c)
+		fmt.Printf("Правая часть №%d\n", c+1)
+		fmt.Println("Решения:")
+		for i, v := range x {
+			fmt.Printf("x%d = %s\n", i+1, formatRat(v))
+		}
+
+		rhs := make([][]*big.Rat, n)
+		for i := range exact {
+			rhs[i] = append(append([]*big.Rat{}, exact[i][:n]...), exact[i][n+c])
+		}
+		deltas := linalg.CalculateDeltasRat(rhs, x)
+		fmt.Print("Невязки: ")
+		for _, d := range deltas {
+			fmt.Print(d.RatString(), " ")
+		}
+		fmt.Println()
+	}
+}
+
+// formatRat дробь и ее приближенное значение
+func formatRat(r *big.Rat) string {
+	f, _ := r.Float64()
+	return fmt.Sprintf("%s ≈ %v", r.RatString(), f)
+}
+
+// solveLeastSquares решение прямоугольной системы m×n через QR-разложение:
+// по методу наименьших квадратов при m > n и с минимальной нормой при m < n
+func solveLeastSquares(matrix linalg.Matrix, n int, known []float64) {
+	a, b := linalg.SplitColumns(matrix, n)
+	if len(a) > n {
+		fmt.Printf("Переопределенная система %dx%d, решение по методу наименьших квадратов (QR)\n", len(a), n)
+	} else {
+		fmt.Printf("Недоопределенная система %dx%d, решение с минимальной нормой (QR)\n", len(a), n)
+	}
+
+	for c := range b {
+		x, err := linalg.LeastSquares(a, b[c])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		deltas := linalg.CalculateDeltas(linalg.Augment(a, b[c]), x)
+		printSolution(c, x, deltas, known)
+		fmt.Println("Норма невязки: ", linalg.VectorNorm(deltas))
+	}
+}
+
+// solveBanded решение ленточной системы: прогонкой для устойчивой
+// трехдиагональной матрицы, иначе ленточным LU-разложением
+func solveBanded(path string) {
+	bm, b, err := linalg.ReadBandFromFile(path)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Ленточная матрица порядка %d, поддиагоналей: %d, наддиагоналей: %d\n", bm.N, bm.P, bm.Q)
+
+	start := time.Now()
+	var x []float64
+	if bm.P == 1 && bm.Q == 1 && linalg.IsTridiagonalStable(bm.Diagonal(-1), bm.Diagonal(0), bm.Diagonal(1)) {
+		fmt.Println("Используется метод прогонки")
+		x, err = linalg.SolveTridiagonal(bm.Diagonal(-1), bm.Diagonal(0), bm.Diagonal(1), b)
+	} else {
+		if bm.P == 1 && bm.Q == 1 {
+			fmt.Println("Условие устойчивости прогонки не выполнено")
+		}
+		fmt.Println("Используется ленточное LU-разложение с выбором главного элемента")
+		var f *linalg.BandLU
+		f, err = linalg.NewBandLU(bm)
+		if err == nil {
+			x = f.Solve(b)
+		}
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	elapsed := time.Since(start)
+
+	printSolutionSummary(0, x, bm.Residual(x, b), nil)
+	fmt.Println("Время решения: ", elapsed)
+}
+
+// solveMatrixMarket решение разреженной системы из файла Matrix Market.
+// Правая часть читается из файла <имя>_b.mtx, а при его отсутствии
+// берется b = A·(1, ..., 1), так что точное решение - единичный вектор
+func solveMatrixMarket(path string) {
+	a, err := linalg.ReadMatrixMarket(path)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if a.Rows != a.Cols {
+		fmt.Println("matrix isn't square")
+		os.Exit(1)
+	}
+
+	var b []float64
+	rhsPath := strings.TrimSuffix(path, ".mtx") + "_b.mtx"
+	if _, statErr := os.Stat(rhsPath); statErr == nil {
+		b, err = linalg.ReadMatrixMarketVector(rhsPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if len(b) != a.Rows {
+			fmt.Println("right side size doesn't match matrix dimension")
+			os.Exit(1)
+		}
+	} else {
+		fmt.Println("Файл правой части", rhsPath, "не найден, используется b = A·(1, ..., 1)")
+		ones := make([]float64, a.Cols)
+		for i := range ones {
+			ones[i] = 1
+		}
+		b = a.MulVec(ones)
+	}
+	solveSparse(a, []linalg.Vector{b}, nil)
+}
+
+// solveSparse решение разреженной системы методом Гаусса-Зейделя
+// или методами Крылова с предобусловливанием
+func solveSparse(a *linalg.CSRMatrix, b []linalg.Vector, known []float64) {
+	fmt.Printf("Разреженная матрица %dx%d, ненулевых элементов: %d\n", a.Rows, a.Cols, a.NonZeros())
+	fmt.Println("Choose sparse method: \"s\" - Gauss-Seidel, \"cg\" - conjugate gradient (SPD matrices)," +
+		" \"bicgstab\" - BiCGSTAB or \"gmres\" - restarted GMRES")
+	var method string
+	if _, err := fmt.Fscan(os.Stdin, &method); err != nil {
+		fmt.Println("error reading method:", err)
+		os.Exit(1)
+	}
+	if method != "s" && method != "cg" && method != "bicgstab" && method != "gmres" {
+		fmt.Println("unknown method:", method)
+		os.Exit(1)
+	}
+	if method == "cg" && !a.IsSymmetric() {
+		fmt.Println("Матрица несимметрична, метод сопряженных градиентов неприменим")
+		os.Exit(1)
+	}
+
+	var precond linalg.Preconditioner
+	if method != "s" {
+		fmt.Println("Choose preconditioner: \"n\" - none, \"j\" - Jacobi or \"ilu\" - ILU(0)")
+		var kind string
+		if _, err := fmt.Fscan(os.Stdin, &kind); err != nil {
+			fmt.Println("error reading preconditioner:", err)
+			os.Exit(1)
+		}
+		var err error
+		precond, err = linalg.NewPreconditioner(a, kind)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	restart := 0
+	if method == "gmres" {
+		fmt.Println("Type GMRES restart length:")
+		if _, err := fmt.Fscan(os.Stdin, &restart); err != nil {
+			fmt.Println("error reading restart length:", err)
+			os.Exit(1)
+		}
+	}
+
+	eps, maxIter, err := readIterationParams()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	saveHistory, err := readSaveResidualHistory()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for c := range b {
+		start := time.Now()
+		var res linalg.IterativeResult
+		switch method {
+		case "s":
+			res, err = linalg.SparseGaussSeidel(a, b[c], eps, maxIter)
+		case "cg":
+			res, err = linalg.ConjugateGradient(a, b[c], precond, eps, maxIter)
+		case "bicgstab":
+			res, err = linalg.BiCGSTAB(a, b[c], precond, eps, maxIter)
+		default:
+			res, err = linalg.GMRES(a, b[c], precond, restart, eps, maxIter)
+		}
+		elapsed := time.Since(start)
+		if err != nil && res.X == nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Количество итераций:", res.Iterations)
+		if len(res.ErrorNorms) > 0 {
+			fmt.Println("Погрешность на последней итерации:", res.ErrorNorms[len(res.ErrorNorms)-1])
+		}
+		if len(res.ResidualHistory) > 0 {
+			printResidualHistory(c, res.ResidualHistory, saveHistory)
+		}
+
+		// проверка ответа: для систем, помещающихся в плотную матрицу, через
+		// CalculateDeltas, для больших - разреженным умножением
+		var deltas []float64
+		if a.Rows <= linalg.MaxFileDimension {
+			deltas = linalg.CalculateDeltas(linalg.Augment(a.Dense(), b[c]), res.X)
+		} else {
+			deltas = a.Residual(res.X, b[c])
+		}
+		if err != nil {
+			// метод не сошелся: выводим последнее приближение и его невязки
+			fmt.Println("Последнее приближение:")
+		}
+		printSolutionSummary(c, res.X, deltas, known)
+		fmt.Println("Время решения: ", elapsed)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(exitCode(err))
+		}
+	}
+}
+
+// readSaveResidualHistory спрашивает, сохранять ли историю невязок в файл
+func readSaveResidualHistory() (bool, error) {
+	fmt.Println("Save the residual history to residuals_<n>.txt for a convergence plot? Type \"y\" or \"n\":")
+	var ans string
+	if _, err := fmt.Fscan(os.Stdin, &ans); err != nil {
+		return false, fmt.Errorf("error reading answer: %v", err)
+	}
+	return ans == "y", nil
+}
+
+// printResidualHistory выводит историю невязок и, если save, сохраняет ее в файл
+// (номер итерации и относительная невязка) для построения графика сходимости
+func printResidualHistory(c int, history []float64, save bool) {
+	var buffer bytes.Buffer
+	for k, r := range history {
+		buffer.WriteString(strconv.Itoa(k+1) + " " + strconv.FormatFloat(r, 'e', 6, 64) + "\n")
+	}
+	if len(history) <= linalg.MaxPrintDimension {
+		fmt.Print("История невязок:\n", buffer.String())
+	}
+	if !save {
+		return
+	}
+
+	path := fmt.Sprintf("residuals_%d.txt", c+1)
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		fmt.Println("error writing residual history:", err)
+		return
+	}
+	fmt.Println("История невязок сохранена в файл", path)
+}
+
+// printSolutionSummary выводит решение целиком для небольших систем,
+// а для больших - только начало и конец вектора и норму невязки
+func printSolutionSummary(c int, x []float64, deltas []float64, known []float64) {
+	if len(x) <= linalg.MaxPrintDimension {
+		printSolution(c, x, deltas, known)
+	} else {
+		fmt.Printf("Правая часть №%d\n", c+1)
+		fmt.Println("Первые решения: ", x[:5])
+		fmt.Println("Последние решения: ", x[len(x)-5:])
+		printSolutionError(x, known)
+	}
+	fmt.Println("Норма невязки: ", linalg.VectorNorm(deltas))
+}
+
+// solveLU решение через LU-разложение, вычисляемое один раз для всех правых частей
+func solveLU(matrix linalg.Matrix, known []float64) {
+	a, b := linalg.SplitAugmented(matrix)
+	var lu *linalg.LU
+	var err error
+	if len(a) >= linalg.BlockedLUThreshold {
+		var workers int
+		workers, err = readWorkers()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		lu, err = linalg.NewBlockedLU(a, linalg.DefaultBlockSize, workers)
+	} else {
+		lu, err = linalg.NewLU(a)
+	}
+	if err == nil && linalg.IsRankDeficient(a) {
+		err = fmt.Errorf("matrix is numerically singular")
+	}
+	if err != nil {
+		fmt.Println(err)
+		printExactDeterminant(a, 0)
+		solveSingular(a, b, nil)
+		return
+	}
+
+	solveFactorized(a, b, lu, known)
+}
+
+// readWorkers читает число горутин для параллельного разложения
+func readWorkers() (int, error) {
+	fmt.Println("Type number of goroutines (0 - number of CPUs):")
+	var workers int
+	if _, err := fmt.Fscan(os.Stdin, &workers); err != nil {
+		return 0, fmt.Errorf("error reading number of goroutines: %v", err)
+	}
+	return workers, nil
+}
+
+// runBenchmark сравнивает последовательное и блочное параллельное
+// LU-разложение на случайных матрицах и выводит производительность в GFLOP/s
+func runBenchmark() {
+	workers, err := readWorkers()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	fmt.Println("Число горутин:", workers)
+
+	for _, n := range []int{500, 1000, 2000} {
+		a, err := linalg.GenerateMatrix(linalg.FamilyUniform, n, rand.New(rand.NewSource(int64(n))), 0)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		start := time.Now()
+		seq, err := linalg.NewLU(a)
+		seqTime := time.Since(start)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		start = time.Now()
+		blocked, err := linalg.NewBlockedLU(a, linalg.DefaultBlockSize, workers)
+		blockedTime := time.Since(start)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		diff, samePerm := linalg.MaxFactorDifference(seq, blocked)
+		fmt.Printf("n = %d\n", n)
+		fmt.Printf("   последовательное: %.3f с, %.2f GFLOP/s\n",
+			seqTime.Seconds(), linalg.LUFlops(n)/seqTime.Seconds()/1e9)
+		fmt.Printf("   блочное:          %.3f с, %.2f GFLOP/s, ускорение %.2f\n",
+			blockedTime.Seconds(), linalg.LUFlops(n)/blockedTime.Seconds()/1e9, seqTime.Seconds()/blockedTime.Seconds())
+		fmt.Printf("   относительное расхождение U: %.3g, перестановки совпадают: %v\n", diff, samePerm)
+	}
+}
+
+// solveSymmetric для симметричной матрицы пробует разложение Холецкого,
+// затем LDLᵀ, а для несимметричной или неразложимой переходит к LU
+func solveSymmetric(matrix linalg.Matrix, known []float64) {
+	a, b := linalg.SplitAugmented(matrix)
+	if !linalg.IsSymmetric(a) {
+		fmt.Println("Матрица несимметрична, используется LU-разложение")
+		solveLU(matrix, known)
+		return
+	}
+	if solveSymmetricFactorized(a, b, known) {
+		return
+	}
+	fmt.Println("Разложение LDLᵀ невозможно, используется LU-разложение")
+	solveLU(matrix, known)
+}
+
+// solveSymmetricFactorized решает систему с симметричной матрицей разложением
+// Холецкого или LDLᵀ. Возвращает false, если ни одно из них не построено
+func solveSymmetricFactorized(a linalg.Matrix, b []linalg.Vector, known []float64) bool {
+	f, err := linalg.FactorSymmetric(a)
+	if err != nil {
+		return false
+	}
+	if _, ok := f.(*linalg.Cholesky); ok {
+		fmt.Println("Матрица симметрична и положительно определена, используется разложение Холецкого")
+	} else {
+		fmt.Println("Матрица симметрична, но не положительно определена, используется разложение LDLᵀ")
+	}
+	solveFactorized(a, b, f, known)
+	return true
+}
+
+// solveFactorized выводит определитель и разложение, затем решает систему
+// для каждой правой части
+func solveFactorized(a linalg.Matrix, b []linalg.Vector, f linalg.Factorization, known []float64) {
+	det := f.Determinant()
+	fmt.Println("Определитель матрицы:", det)
+	printExactDeterminant(a, det)
+	printFactorization(f)
+	printConditionNumbers(linalg.FactorizationConditioning(a, f))
+
+	refine, err := readRefinement()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for c := range b {
+		if refine {
+			printRefinedSolution(c, a, b[c], f, known)
+			continue
+		}
+		x := f.Solve(b[c])
+		printSolution(c, x, linalg.CalculateDeltas(linalg.Augment(a, b[c]), x), known)
+	}
+}
+
+// readRefinement спрашивает, нужно ли итерационное уточнение решения
+func readRefinement() (bool, error) {
+	fmt.Println("Apply iterative refinement of the solution? Type \"y\" or \"n\":")
+	var ans string
+	if _, err := fmt.Fscan(os.Stdin, &ans); err != nil {
+		return false, fmt.Errorf("error reading answer: %v", err)
+	}
+	return ans == "y", nil
+}
+
+// printRefinedSolution уточняет решение для правой части с номером c по
+// готовому разложению f и выводит норму невязки на каждом шаге
+func printRefinedSolution(c int, a linalg.Matrix, b linalg.Vector, f linalg.Factorization, known []float64) {
+	res := linalg.IterativeRefinement(a, b, f)
+	fmt.Println("Норма невязки до уточнения:", res.ResidualNorms[0])
+	for k, norm := range res.ResidualNorms[1:] {
+		fmt.Printf("Шаг уточнения %d, норма невязки: %g\n", k+1, norm)
+	}
+	printSolution(c, res.X, linalg.CalculateDeltas(linalg.Augment(a, b), res.X), known)
+}
+
+// printExactDeterminant для целочисленной матрицы небольшого порядка
+// вычисляет определитель методом Барейса и сверяет его с определителем в float64
+func printExactDeterminant(a linalg.Matrix, det float64) {
+	if !linalg.IsIntegerMatrix(a) {
+		return
+	}
+	if len(a) > linalg.MaxExactDimension {
+		fmt.Printf("Точный определитель не вычисляется для матриц порядка больше %d\n", linalg.MaxExactDimension)
+		return
+	}
+	exact, err := linalg.BareissDeterminant(a)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("Точный определитель (метод Барейса):", exact)
+	if rel, ok := linalg.CompareDeterminant(exact, det); !ok {
+		fmt.Printf("ВНИМАНИЕ: определитель в float64 расходится с точным, относительная погрешность %g\n", rel)
+	}
+}
+
+// printMatrix выводит матрицу построчно с выравниванием столбцов
+func printMatrix(w io.Writer, name string, m linalg.Matrix) {
+	if len(m) > linalg.MaxPrintDimension {
+		fmt.Fprintf(w, "%s: матрица %dx%d не выводится\n", name, len(m), len(m[0]))
+		return
+	}
+	fmt.Fprintln(w, name+":")
+	for _, row := range m {
+		for _, v := range row {
+			fmt.Fprintf(w, "%12.6g ", v)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// printFactorization выводит матрицы разложения
+func printFactorization(f linalg.Factorization) {
+	switch f := f.(type) {
+	case *linalg.LU:
+		printMatrix(os.Stdout, "L", f.L)
+		printMatrix(os.Stdout, "U", f.U)
+		fmt.Println("P:", f.P)
+	case *linalg.Cholesky:
+		printMatrix(os.Stdout, "L", f.L)
+	case *linalg.LDLT:
+		printMatrix(os.Stdout, "L", f.L)
+		fmt.Println("D:", f.D)
+	}
+}
+
+// printConditioning вычисляет обратную матрицу через LU-разложение
+// и выводит числа обусловленности
+func printConditioning(a linalg.Matrix) {
+	inv, cond, err := linalg.Conditioning(a)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	printConditionNumbers(inv, cond)
+}
+
+// printConditionNumbers выводит обратную матрицу, числа обусловленности
+// и предупреждение, если решению нельзя доверять
+func printConditionNumbers(inv linalg.Matrix, cond linalg.ConditionNumbers) {
+	printMatrix(os.Stdout, "Обратная матрица", inv)
+	fmt.Println("Число обусловленности (норма 1):", cond.Cond1)
+	fmt.Println("Число обусловленности (норма 2, оценка):", cond.Cond2)
+	fmt.Println("Число обусловленности (норма ∞):", cond.CondInf)
+	if cond.Meaningless() {
+		fmt.Println("ВНИМАНИЕ: cond(A)·eps >= 1, полученное решение не имеет смысла")
+	} else if cond.LostDigits() >= 8 {
+		fmt.Printf("ВНИМАНИЕ: матрица плохо обусловлена, теряется около %.0f значащих цифр\n", cond.LostDigits())
+	}
+}
+
+// solveSingular исследует вырожденную систему для каждой правой части
+// и выводит общее решение, если оно существует. Преобразования
+// записываются в trace, если он не nil
+func solveSingular(a linalg.Matrix, b []linalg.Vector, trace *linalg.EliminationTrace) {
+	fmt.Println("Матрица вырождена, исследование системы на совместность")
+	for c := range b {
+		sol := linalg.ClassifySystem(a, b[c], trace)
+		fmt.Printf("Правая часть №%d\n", c+1)
+		fmt.Println("Ранг матрицы:", sol.Rank, "\nРанг расширенной матрицы:", sol.AugmentedRank)
+		fmt.Println("Результат:", sol.Kind)
+		if sol.Kind == linalg.SystemInconsistent {
+			continue
+		}
+
+		fmt.Println("Частное решение: ", sol.Particular)
+		fmt.Println("Невязки: ", linalg.CalculateDeltas(linalg.Augment(a, b[c]), sol.Particular))
+		if len(sol.NullSpace) == 0 {
+			continue
+		}
+		fmt.Println("Базис решений однородной системы:")
+		for i, v := range sol.NullSpace {
+			fmt.Printf("v%d = %v\n", i+1, v)
+		}
+		general := "x = x0"
+		for i := range sol.NullSpace {
+			general += fmt.Sprintf(" + t%d*v%d", i+1, i+1)
+		}
+		fmt.Println("Общее решение:", general)
+	}
+}
+
+// solveIterative решение итерационными методами Якоби, Гаусса-Зейделя или SOR
+func solveIterative(matrix linalg.Matrix, method string, known []float64) {
+	eps, maxIter, err := readIterationParams()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	a, b := linalg.SplitAugmented(matrix)
+	omega, autoOmega := 1.0, false
+	if method == "o" {
+		omega, autoOmega, err = readOmega()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	for c := range b {
+		pa, pb, dominant, permuted := linalg.PrepareIterative(a, b[c])
+		if permuted {
+			fmt.Println("Строки переставлены для достижения диагонального преобладания")
+		}
+		if !dominant {
+			fmt.Println("Диагональное преобладание не достигнуто, сходимость не гарантируется")
+		}
+		if autoOmega {
+			omega = linalg.EstimateOmega(pa)
+		}
+		if method == "o" {
+			fmt.Println("Параметр релаксации:", omega)
+		}
+
+		var res linalg.IterativeResult
+		switch method {
+		case "j":
+			res, err = linalg.Jacobi(pa, pb, eps, maxIter)
+		case "s":
+			res, err = linalg.GaussSeidel(pa, pb, eps, maxIter)
+		default:
+			res, err = linalg.SOR(pa, pb, omega, eps, maxIter)
+		}
+		if err != nil && res.X == nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Количество итераций:", res.Iterations)
+		for k, errVec := range res.Errors {
+			fmt.Printf("Итерация %d, вектор погрешностей: %v\n", k+1, errVec)
+		}
+		if err != nil {
+			// метод не сошелся: выводим последнее приближение и его невязки
+			fmt.Println("Последнее приближение:")
+		}
+		printSolution(c, res.X, linalg.CalculateDeltas(linalg.Augment(a, b[c]), res.X), known)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(exitCode(err))
+		}
+	}
+}
+
+// solveEigen поиск собственных значений и векторов матрицы a (n×n)
+func solveEigen(a linalg.Matrix) {
+	fmt.Println("Choose eigen method: \"p\" - power iteration (eigenvalue farthest from shift)," +
+		" \"i\" - inverse iteration (eigenvalue nearest to shift) or \"q\" - QR algorithm (full spectrum)")
+	var method string
+	if _, err := fmt.Fscan(os.Stdin, &method); err != nil {
+		fmt.Println("error reading method:", err)
+		os.Exit(1)
+	}
+
+	switch method {
+	case "p", "i":
+		eps, maxIter, err := readIterationParams()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("Type shift:")
+		var shift float64
+		if _, err := fmt.Fscan(os.Stdin, &shift); err != nil {
+			fmt.Println("error reading shift:", err)
+			os.Exit(1)
+		}
+
+		var res linalg.EigenResult
+		if method == "p" {
+			res, err = linalg.PowerIteration(a, shift, eps, maxIter)
+		} else {
+			res, err = linalg.InverseIteration(a, shift, eps, maxIter)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("Количество итераций:", res.Iterations)
+		fmt.Println("Собственное значение:", res.Value)
+		if len(res.Vector) <= linalg.MaxPrintDimension {
+			fmt.Println("Собственный вектор:", res.Vector)
+		}
+		fmt.Println("Невязка ||Av - λv||:", res.Residual)
+	case "q":
+		pairs, err := linalg.EigenDecomposition(a)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for k, p := range pairs {
+			fmt.Printf("λ%d = %s\n", k+1, formatComplex(p.Value))
+			if len(p.Vector) <= linalg.MaxPrintDimension {
+				parts := make([]string, len(p.Vector))
+				for i, x := range p.Vector {
+					parts[i] = formatComplex(x)
+				}
+				fmt.Printf("   v%d = [%s]\n", k+1, strings.Join(parts, " "))
+			}
+			fmt.Printf("   невязка ||Av - λv|| = %g\n", p.Residual)
+		}
+	default:
+		fmt.Println("unknown eigen method:", method)
+		os.Exit(1)
+	}
+}
 
-	deltas := CalculateDeltas(matrix, x)
+// solveComplexSystem решение комплексной системы методом Гаусса с выводом
+// определителя и решений в алгебраической и показательной формах
+func solveComplexSystem(path string) {
+	matrix, n, err := linalg.ReadComplexMatrixFromFile(path)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(matrix) != n {
+		fmt.Println("complex systems must be square")
+		os.Exit(1)
+	}
+
+	m, det, err := linalg.Eliminate(matrix)
+	if err != nil {
+		fmt.Println("Определитель: 0")
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Определитель: %s = %s\n", formatComplex(det), formatPolar(det))
+
+	if n <= linalg.MaxPrintDimension {
+		fmt.Println("Треугольная матрица:")
+		for _, row := range m {
+			parts := make([]string, len(row))
+			for j, z := range row {
+				parts[j] = formatComplex(z)
+			}
+			fmt.Println(strings.Join(parts, "  "))
+		}
+	}
+
+	for c := 0; c < len(matrix[0])-n; c++ {
+		x := linalg.BackSubstitute(m, c)
+		rhs := make([]complex128, n)
+		for i := range matrix {
+			rhs[i] = matrix[i][n+c]
+		}
+		deltas := linalg.Residual(linalg.Augment(matrixColumns(matrix, n), rhs), x)
+
+		fmt.Printf("Правая часть №%d\n", c+1)
+		for i, z := range x {
+			fmt.Printf("x%d = %s = %s\n", i+1, formatComplex(z), formatPolar(z))
+		}
+		moduli := make([]float64, n)
+		for i := range deltas {
+			moduli[i] = cmplx.Abs(deltas[i])
+		}
+		fmt.Println("Модули невязок: ", moduli)
+	}
+}
+
+// matrixColumns первые n столбцов матрицы
+func matrixColumns[T linalg.Scalar](matrix [][]T, n int) [][]T {
+	a := make([][]T, len(matrix))
+	for i := range matrix {
+		a[i] = matrix[i][:n]
+	}
+	return a
+}
+
+// formatPolar выводит комплексное число в показательной форме r∠φ
+// с углом в градусах и радианах
+func formatPolar(z complex128) string {
+	phi := cmplx.Phase(z)
+	return fmt.Sprintf("%.10g∠%.6g° (φ = %.10g рад)", cmplx.Abs(z), phi*180/math.Pi, phi)
+}
+
+// formatComplex выводит комплексное число, опуская нулевую мнимую часть
+func formatComplex(z complex128) string {
+	if imag(z) == 0 {
+		return strconv.FormatFloat(real(z), 'g', 10, 64)
+	}
+	return fmt.Sprintf("%.10g%+.10gi", real(z), imag(z))
+}
+
+func readIterationParams() (float64, int, error) {
+	fmt.Println("Type accuracy and max number of iterations:")
+	var eps float64
+	var maxIter int
+	if _, err := fmt.Fscan(os.Stdin, &eps, &maxIter); err != nil {
+		return 0, 0, fmt.Errorf("error reading iteration parameters: %v", err)
+	}
+	if eps <= 0 || maxIter <= 0 {
+		return 0, 0, fmt.Errorf("accuracy and number of iterations must be positive")
+	}
+	return eps, maxIter, nil
+}
+
+// readOmega читает параметр релаксации, второе значение - признак автоматической оценки
+func readOmega() (float64, bool, error) {
+	fmt.Println("Type relaxation parameter from (0, 2) or \"a\" to estimate it automatically:")
+	var ans string
+	if _, err := fmt.Fscan(os.Stdin, &ans); err != nil {
+		return 0, false, fmt.Errorf("error reading relaxation parameter: %v", err)
+	}
+	if ans == "a" {
+		return 0, true, nil
+	}
+	omega, err := strconv.ParseFloat(ans, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing relaxation parameter: %v", err)
+	}
+	return omega, false, nil
+}
+
+// printSolution выводит вектор решений и невязок для правой части с номером c
+// и погрешность, если известно точное решение known
+func printSolution(c int, x []float64, deltas []float64, known []float64) {
+	fmt.Printf("Правая часть №%d\n", c+1)
+	fmt.Println("Решения: ", x)
 	fmt.Println("Невязки: ", deltas)
+	printSolutionError(x, known)
+}
+
+// printSolutionError выводит погрешность решения относительно известного точного
+func printSolutionError(x []float64, known []float64) {
+	if known == nil {
+		return
+	}
+	errVec := make([]float64, len(x))
+	maximum := 0.0
+	for i := range x {
+		errVec[i] = x[i] - known[i]
+		maximum = math.Max(maximum, math.Abs(errVec[i]))
+	}
+	if len(x) <= linalg.MaxPrintDimension {
+		fmt.Println("Погрешность решения: ", errVec)
+	}
+	fmt.Println("Максимальная погрешность решения: ", maximum)
+	fmt.Println("Относительная погрешность решения: ", linalg.VectorNorm(errVec)/linalg.VectorNorm(known))
+}
+
+func readPivotStrategy() (linalg.PivotStrategy, error) {
+	fmt.Println("Choose pivoting strategy: \"n\" - none, \"p\" - partial (by column max)" +
+		" or \"c\" - complete (with column permutation)")
+	var ans string
+	_, err := fmt.Fscan(os.Stdin, &ans)
+	if err != nil {
+		return linalg.PivotNone, fmt.Errorf("error reading pivoting strategy: %v", err)
+	}
+
+	switch ans {
+	case "n":
+		return linalg.PivotNone, nil
+	case "p":
+		return linalg.PivotPartial, nil
+	case "c":
+		return linalg.PivotComplete, nil
+	default:
+		return linalg.PivotNone, fmt.Errorf("unknown pivoting strategy: %v", ans)
+	}
 }
 
 func makeEmptyMatrix(n int) [][]float64 {
@@ -134,64 +1156,6 @@ func ReadMatrixFromKeyboard() ([][]float64, error) {
 	return matrix, nil
 }
 
-func ReadMatrixFromFile(path string) ([][]float64, error) {
-	file, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("error reading file: %v", err)
-	}
-	strings.TrimSpace(string(file))
-
-	rows := strings.Split(string(file), "\n")
-	for i, row := range rows {
-		if len(row) == 0 {
-			rows = append(rows[:i], rows[i+1:]...)
-		}
-	}
-
-	if len(rows) == 0 {
-		return nil, fmt.Errorf("file is empty: %v", err)
-	}
-
-	n, err := strconv.Atoi(strings.TrimSpace(rows[0]))
-	if err != nil {
-		return nil, fmt.Errorf("error converting matrix dimension: %v", err)
-	}
-	dimErr := checkDimensions(n)
-	if dimErr != nil {
-		return nil, dimErr
-	}
-
-	if len(rows)-1 < 2 || len(rows)-1 > 20 {
-		return nil, fmt.Errorf("unsupported matrix dimension")
-	}
-
-	for i := 1; i < n; i++ {
-		rows[i] = strings.TrimSpace(rows[i])
-		if len(strings.Split(rows[i], " ")) != n+1 {
-			return nil, fmt.Errorf("matrix isn't square or you forgot right side of equations or its empty")
-		}
-	}
-
-	if len(rows)-1 != n {
-		return nil, fmt.Errorf("number of matrix rows doesn't match dimension")
-	}
-
-	matrix := makeEmptyMatrix(n)
-
-	for i := 0; i < n; i++ {
-		rowNums := strings.Split(rows[i+1], " ")
-		for j := 0; j < n+1; j++ {
-			num, err := strconv.ParseFloat(rowNums[j], 64)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing matrix number: %v", err)
-			}
-			matrix[i][j] = num
-		}
-	}
-
-	return matrix, nil
-}
-
 func readFilePath() (string, error) {
 	fmt.Println("Enter valid path to file:")
 	var path string
@@ -203,47 +1167,156 @@ func readFilePath() (string, error) {
 	return path, nil
 }
 
+// GenerateRandomMatrixFile генерирует матрицу выбранного семейства с воспроизводимым
+// зерном и записывает ее в файл. Если правая часть строится по известному
+// решению, оно записывается в файл <имя>_x<расширение>
 func GenerateRandomMatrixFile() (string, error) {
 	n := 0
-	fmt.Println("Type n - matrix dimension from 2 to 20 (only the first number is read):")
+	fmt.Printf("Type n - matrix dimension from 2 to %d (only the first number is read):\n", linalg.MaxFileDimension)
 
 	_, err := fmt.Fscan(os.Stdin, &n)
 	if err != nil {
 		return "", fmt.Errorf("error reading dimensions: %v", err)
 	}
-	dimErr := checkDimensions(n)
-	if dimErr != nil {
-		return "", dimErr
+	if n < 2 || n > linalg.MaxFileDimension {
+		return "", fmt.Errorf("unsupported dimensions: %v", n)
+	}
+
+	fmt.Println("Choose matrix family: \"u\" - uniform real values, \"i\" - uniform integers," +
+		" \"d\" - diagonally dominant, \"s\" - symmetric positive definite, \"h\" - Hilbert," +
+		" \"v\" - Vandermonde, \"t\" - tridiagonal, \"c\" - prescribed condition number")
+	var family string
+	if _, err := fmt.Fscan(os.Stdin, &family); err != nil {
+		return "", fmt.Errorf("error reading matrix family: %v", err)
+	}
+	cond := 0.0
+	if linalg.MatrixFamily(family) == linalg.FamilyCondition {
+		fmt.Println("Type condition number:")
+		if _, err := fmt.Fscan(os.Stdin, &cond); err != nil {
+			return "", fmt.Errorf("error reading condition number: %v", err)
+		}
+	}
+
+	fmt.Println("Type seed (integer) or \"t\" to use current time:")
+	var seedStr string
+	if _, err := fmt.Fscan(os.Stdin, &seedStr); err != nil {
+		return "", fmt.Errorf("error reading seed: %v", err)
 	}
+	seed := time.Now().UnixNano()
+	if seedStr != "t" {
+		seed, err = strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("error parsing seed: %v", err)
+		}
+	}
+	fmt.Println("Зерно генератора:", seed)
+	var rnd = rand.New(rand.NewSource(seed))
 
-	//matrix := makeEmptyMatrix(n)
-	var rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	fmt.Println("Build right side from known solution x (b = Ax)? Type \"y\" or \"n\":")
+	var known string
+	if _, err := fmt.Fscan(os.Stdin, &known); err != nil {
+		return "", fmt.Errorf("error reading answer: %v", err)
+	}
 
-	file, err := os.Create("matrix.txt")
+	fmt.Println("Type output file name or \"-\" for matrix.txt:")
+	var path string
+	if _, err := fmt.Fscan(os.Stdin, &path); err != nil {
+		return "", fmt.Errorf("error reading file name: %v", err)
+	}
+	if path == "-" {
+		path = "matrix.txt"
+	}
+
+	a, err := linalg.GenerateMatrix(linalg.MatrixFamily(family), n, rnd, cond)
 	if err != nil {
-		return "", fmt.Errorf("error creating file: %v", err)
+		return "", err
 	}
-	// close fo on exit and check for its returned error
-	defer func() {
-		if err := file.Close(); err != nil {
-			fmt.Printf("error closing file: %v", err)
+
+	var x, b []float64
+	if known == "y" {
+		x, b = linalg.GenerateSolution(a, rnd)
+	} else {
+		// правая часть того же вида, что и элементы матрицы
+		b = make([]float64, n)
+		for i := range b {
+			if linalg.MatrixFamily(family) == linalg.FamilyUniform {
+				b[i] = math.Round(((-15)+rnd.Float64()*(15-(-15)))*1e4) / 1e4
+			} else {
+				b[i] = float64(rnd.Intn(31) - 15)
+			}
 		}
-	}()
+	}
 
 	var buffer bytes.Buffer
 	buffer.Write([]byte(strconv.Itoa(n) + "\n"))
 	for i := 0; i < n; i++ {
-		for j := 0; j < n+1; j++ {
-			// min + rand.Float64() * (max - min)
-			buffer.Write([]byte(strconv.FormatFloat((-15)+rnd.Float64()*(15-(-15)), 'f', 4, 64) + " "))
-			//matrix[i][j] = (-15) + rnd.Float64()*(15-(-15))
+		for j := 0; j < n; j++ {
+			buffer.Write([]byte(strconv.FormatFloat(a[i][j], 'g', -1, 64) + " "))
 		}
-		buffer.Write([]byte("\n"))
+		buffer.Write([]byte(strconv.FormatFloat(b[i], 'g', -1, 64) + "\n"))
 	}
 
-	if _, err := file.Write(buffer.Bytes()); err != nil {
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
 		return "", fmt.Errorf("error writing to file: %v", err)
 	}
 
-	return file.Name(), nil
+	if x != nil {
+		if err := writeVector(solutionPath(path), x); err != nil {
+			return "", err
+		}
+		fmt.Println("Известное решение записано в файл", solutionPath(path))
+	}
+
+	return path, nil
+}
+
+// solutionPath путь к файлу с известным решением для файла матрицы
+func solutionPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "_x" + ext
+}
+
+// writeVector записывает вектор в одну строку через пробел
+func writeVector(path string, v []float64) error {
+	parts := make([]string, len(v))
+	for i := range v {
+		parts[i] = strconv.FormatFloat(v[i], 'g', -1, 64)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(parts, " ")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing to file: %v", err)
+	}
+	return nil
+}
+
+// readKnownSolution спрашивает, нужно ли сравнить решение с известным точным,
+// и читает его из файла <имя>_x<расширение>. Сравнение возможно только для
+// системы с одной правой частью rhs. Возвращает nil, если сравнение не нужно
+func readKnownSolution(path string, n int, rhs int) ([]float64, error) {
+	fmt.Printf("Compare the solution with the known exact one from %s? Type \"y\" or \"n\":\n", solutionPath(path))
+	var ans string
+	if _, err := fmt.Fscan(os.Stdin, &ans); err != nil {
+		return nil, fmt.Errorf("error reading answer: %v", err)
+	}
+	if ans != "y" {
+		return nil, nil
+	}
+	if rhs != 1 {
+		return nil, fmt.Errorf("known solution can be compared only for a system with one right side, got %d", rhs)
+	}
+	data, err := os.ReadFile(solutionPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading known solution: %v", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != n {
+		return nil, fmt.Errorf("known solution must contain %d numbers, got %d", n, len(fields))
+	}
+	x := make([]float64, n)
+	for i := range fields {
+		x[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing known solution: %v", err)
+		}
+	}
+	return x, nil
 }
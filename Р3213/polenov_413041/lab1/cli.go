@@ -0,0 +1,478 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+	"os"
+	"strings"
+	"time"
+
+	"newOne/linalg"
+)
+
+// Коды завершения неинтерактивного режима
+const (
+	exitOK = 0
+	// exitUsage неверные аргументы командной строки
+	exitUsage = 2
+	// exitInput ошибка чтения или разбора входного файла
+	exitInput = 3
+	// exitSingular матрица вырождена
+	exitSingular = 4
+	// exitNoConvergence итерационный метод не сошелся
+	exitNoConvergence = 5
+	// exitOutput ошибка записи результата
+	exitOutput = 6
+)
+
+// CLITimings время этапов решения в миллисекундах
+type CLITimings struct {
+	Read  float64 `json:"read_ms"`
+	Solve float64 `json:"solve_ms"`
+	Total float64 `json:"total_ms"`
+}
+
+// CLIResult результат решения в неинтерактивном режиме
+type CLIResult struct {
+	Input  string `json:"input"`
+	Method string `json:"method"`
+	N      int    `json:"n"`
+	// Determinant отсутствует для итерационных методов и при переполнении
+	Determinant *float64 `json:"determinant,omitempty"`
+	// Triangular треугольная матрица прямого хода (U для LU-разложения,
+	// L для разложений Холецкого и LDLᵀ)
+	Triangular cliMatrix `json:"triangular,omitempty"`
+	Solutions  cliMatrix `json:"solutions,omitempty"`
+	// Residuals невязки, для комплексной системы - их модули
+	Residuals cliMatrix `json:"residuals,omitempty"`
+	// Определитель, треугольная матрица и решения системы с комплексными
+	// коэффициентами, числа записываются парами [re, im]
+	ComplexDeterminant *cliComplex      `json:"complex_determinant,omitempty"`
+	ComplexTriangular  cliComplexMatrix `json:"complex_triangular,omitempty"`
+	ComplexSolutions   cliComplexMatrix `json:"complex_solutions,omitempty"`
+	Iterations         []int            `json:"iterations,omitempty"`
+	// Trace файл с трассой преобразований метода Гаусса
+	Trace    string     `json:"trace,omitempty"`
+	Timings  CLITimings `json:"timings"`
+	Error    string     `json:"error,omitempty"`
+	ExitCode int        `json:"exit_code"`
+}
+
+// cliMatrix матрица или набор векторов результата. Бесконечности и NaN,
+// которые нельзя записать в JSON, записываются как null
+type cliMatrix [][]float64
+
+func (m cliMatrix) MarshalJSON() ([]byte, error) {
+	rows := make([][]*float64, len(m))
+	for i := range m {
+		rows[i] = make([]*float64, len(m[i]))
+		for j, v := range m[i] {
+			rows[i][j] = finite(v)
+		}
+	}
+	return json.Marshal(rows)
+}
+
+// cliComplex комплексное число результата
+type cliComplex complex128
+
+func (z cliComplex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(complexPair(complex128(z)))
+}
+
+// cliComplexMatrix комплексная матрица или набор комплексных векторов результата
+type cliComplexMatrix [][]complex128
+
+func (m cliComplexMatrix) MarshalJSON() ([]byte, error) {
+	rows := make([][][]*float64, len(m))
+	for i := range m {
+		rows[i] = make([][]*float64, len(m[i]))
+		for j, z := range m[i] {
+			rows[i][j] = complexPair(z)
+		}
+	}
+	return json.Marshal(rows)
+}
+
+// complexPair пара [re, im], бесконечности и NaN записываются как null
+func complexPair(z complex128) []*float64 {
+	return []*float64{finite(real(z)), finite(imag(z))}
+}
+
+// cliOptions параметры командной строки
+type cliOptions struct {
+	in, method, pivot, format, out string
+	trace, traceOut                string
+	eps, omega                     float64
+	maxIter                        int
+}
+
+// RunCLI решает систему без диалога по аргументам командной строки
+// и возвращает код завершения
+func RunCLI(args []string) int {
+	var opts cliOptions
+	fs := flag.NewFlagSet("lab1", flag.ContinueOnError)
+	fs.StringVar(&opts.in, "in", "", "input file with the augmented matrix (.txt, .csv or .json)")
+	fs.StringVar(&opts.method, "method", "gauss", "solution method: gauss, lu, cholesky (LDLᵀ for indefinite matrices), jacobi, seidel or sor")
+	fs.StringVar(&opts.pivot, "pivot", "partial", "pivoting strategy for gauss: none, partial or complete")
+	fs.StringVar(&opts.trace, "trace", "", "export elimination trace for gauss: text, md or tex")
+	fs.StringVar(&opts.traceOut, "traceout", "", "trace file (trace.txt, trace.md or trace.tex by default)")
+	fs.Float64Var(&opts.eps, "eps", 1e-6, "accuracy of iterative methods")
+	fs.IntVar(&opts.maxIter, "maxiter", 1000, "max number of iterations")
+	fs.Float64Var(&opts.omega, "omega", 1, "relaxation parameter for sor")
+	fs.StringVar(&opts.format, "format", "text", "output format: text or json")
+	fs.StringVar(&opts.out, "out", "", "output file (standard output by default)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if opts.in == "" {
+		fmt.Fprintln(os.Stderr, "flag -in is required")
+		return exitUsage
+	}
+	if opts.format != "text" && opts.format != "json" {
+		fmt.Fprintln(os.Stderr, "unknown output format:", opts.format)
+		return exitUsage
+	}
+	if opts.trace != "" && opts.trace != "text" && opts.trace != "md" && opts.trace != "tex" {
+		fmt.Fprintln(os.Stderr, "unknown trace format:", opts.trace)
+		return exitUsage
+	}
+	if opts.trace != "" && opts.method != "gauss" {
+		fmt.Fprintln(os.Stderr, "flag -trace is supported only for method gauss")
+		return exitUsage
+	}
+	pivot, ok := map[string]linalg.PivotStrategy{"none": linalg.PivotNone, "partial": linalg.PivotPartial, "complete": linalg.PivotComplete}[opts.pivot]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "unknown pivoting strategy:", opts.pivot)
+		return exitUsage
+	}
+
+	res := solveCLI(opts, pivot)
+	if res.ExitCode == exitUsage {
+		fmt.Fprintln(os.Stderr, res.Error)
+		return exitUsage
+	}
+	if res.Error != "" && (opts.format == "json" || opts.out != "") {
+		// в текстовом выводе на экран ошибка уже есть
+		fmt.Fprintln(os.Stderr, res.Error)
+	}
+
+	w := io.Writer(os.Stdout)
+	var file *os.File
+	if opts.out != "" {
+		var err error
+		file, err = os.Create(opts.out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error creating output file:", err)
+			return exitOutput
+		}
+		w = file
+	}
+
+	var err error
+	if opts.format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(res)
+	} else {
+		err = writeCLIText(w, res)
+	}
+	if file != nil {
+		// ошибка записи на диск может проявиться только при закрытии файла
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error writing result:", err)
+		return exitOutput
+	}
+	return res.ExitCode
+}
+
+// solveCLI читает систему и решает ее выбранным методом
+func solveCLI(opts cliOptions, pivot linalg.PivotStrategy) CLIResult {
+	start := time.Now()
+	res := CLIResult{Input: opts.in, Method: opts.method}
+	fail := func(code int, err error) CLIResult {
+		res.ExitCode = code
+		res.Error = err.Error()
+		res.Timings.Total = milliseconds(time.Since(start))
+		return res
+	}
+
+	if linalg.IsComplexFile(opts.in) {
+		return solveComplexCLI(opts, start)
+	}
+	matrix, n, err := linalg.ReadMatrixFromFile(opts.in)
+	res.Timings.Read = milliseconds(time.Since(start))
+	if err != nil {
+		return fail(exitInput, err)
+	}
+	if len(matrix) != n {
+		return fail(exitInput, fmt.Errorf("%w: non-interactive mode supports only square systems", linalg.ErrDimension))
+	}
+	res.N = n
+	a, b := linalg.SplitAugmented(matrix)
+
+	solveStart := time.Now()
+	switch opts.method {
+	case "gauss":
+		var trace *linalg.EliminationTrace
+		if opts.trace != "" {
+			trace = &linalg.EliminationTrace{}
+		}
+		det, m, order, err := linalg.Determinant(matrix, pivot, trace)
+		res.Determinant = finite(det)
+		if trace != nil {
+			// трасса сохраняется и для вырожденной матрицы
+			path := opts.traceOut
+			if path == "" {
+				path = traceFileName(opts.trace)
+			}
+			if err := trace.Export(path, opts.trace); err != nil {
+				return fail(exitOutput, err)
+			}
+			res.Trace = path
+		}
+		if err == nil && linalg.IsRankDeficient(a) {
+			err = fmt.Errorf("%w (rank deficient)", linalg.ErrSingular)
+		}
+		if err != nil {
+			return fail(exitCode(err), err)
+		}
+		res.Triangular = cliMatrix(m)
+		for c := range b {
+			res.Solutions = append(res.Solutions, linalg.GaussSolverBackward(m, order, c))
+		}
+	case "lu":
+		var lu *linalg.LU
+		if n >= linalg.BlockedLUThreshold {
+			lu, err = linalg.NewBlockedLU(a, linalg.DefaultBlockSize, 0)
+		} else {
+			lu, err = linalg.NewLU(a)
+		}
+		if err == nil && linalg.IsRankDeficient(a) {
+			err = fmt.Errorf("%w (rank deficient)", linalg.ErrSingular)
+		}
+		if err != nil {
+			return fail(exitCode(err), err)
+		}
+		res.Determinant = finite(lu.Determinant())
+		res.Triangular = lu.U
+		for c := range b {
+			res.Solutions = append(res.Solutions, lu.Solve(b[c]))
+		}
+	case "cholesky":
+		if !linalg.IsSymmetric(a) {
+			return fail(exitUsage, fmt.Errorf("%w: method cholesky requires a symmetric matrix", linalg.ErrInvalidArgument))
+		}
+		f, err := linalg.FactorSymmetric(a)
+		if err != nil {
+			return fail(exitCode(err), err)
+		}
+		// для знаконеопределенной матрицы строится разложение LDLᵀ
+		switch f := f.(type) {
+		case *linalg.Cholesky:
+			res.Triangular = f.L
+		case *linalg.LDLT:
+			res.Method, res.Triangular = "ldlt", f.L
+		}
+		res.Determinant = finite(f.Determinant())
+		for c := range b {
+			res.Solutions = append(res.Solutions, f.Solve(b[c]))
+		}
+	case "jacobi", "seidel", "sor":
+		if opts.eps <= 0 || opts.maxIter <= 0 {
+			return fail(exitUsage, fmt.Errorf("accuracy and number of iterations must be positive"))
+		}
+		for c := range b {
+			pa, pb, _, _ := linalg.PrepareIterative(a, b[c])
+			var it linalg.IterativeResult
+			switch opts.method {
+			case "jacobi":
+				it, err = linalg.Jacobi(pa, pb, opts.eps, opts.maxIter)
+			case "seidel":
+				it, err = linalg.GaussSeidel(pa, pb, opts.eps, opts.maxIter)
+			default:
+				it, err = linalg.SOR(pa, pb, opts.omega, opts.eps, opts.maxIter)
+			}
+			if err != nil {
+				return fail(exitCode(err), err)
+			}
+			res.Solutions = append(res.Solutions, it.X)
+			res.Iterations = append(res.Iterations, it.Iterations)
+		}
+	default:
+		return fail(exitUsage, fmt.Errorf("unknown method: %v", opts.method))
+	}
+	res.Timings.Solve = milliseconds(time.Since(solveStart))
+
+	for c, x := range res.Solutions {
+		res.Residuals = append(res.Residuals, linalg.CalculateDeltas(linalg.Augment(a, b[c]), x))
+		for _, v := range x {
+			if finite(v) == nil {
+				// переполнение при решении почти вырожденной системы
+				return fail(exitSingular, fmt.Errorf("%w: solution %d contains infinite or NaN values", linalg.ErrSingular, c+1))
+			}
+		}
+	}
+	res.Timings.Total = milliseconds(time.Since(start))
+	return res
+}
+
+// solveComplexCLI решает систему с комплексными коэффициентами методом Гаусса
+// с выбором главного элемента по столбцу
+func solveComplexCLI(opts cliOptions, start time.Time) CLIResult {
+	res := CLIResult{Input: opts.in, Method: opts.method}
+	fail := func(code int, err error) CLIResult {
+		res.ExitCode = code
+		res.Error = err.Error()
+		res.Timings.Total = milliseconds(time.Since(start))
+		return res
+	}
+	switch {
+	case opts.method != "gauss":
+		return fail(exitUsage, fmt.Errorf("%w: complex systems are solved only by method gauss", linalg.ErrInvalidArgument))
+	case opts.pivot != "partial":
+		return fail(exitUsage, fmt.Errorf("%w: complex systems support only partial pivoting", linalg.ErrInvalidArgument))
+	case opts.trace != "":
+		return fail(exitUsage, fmt.Errorf("%w: trace is not supported for complex systems", linalg.ErrInvalidArgument))
+	}
+
+	matrix, n, err := linalg.ReadComplexMatrixFromFile(opts.in)
+	res.Timings.Read = milliseconds(time.Since(start))
+	if err != nil {
+		return fail(exitInput, err)
+	}
+	if len(matrix) != n {
+		return fail(exitInput, fmt.Errorf("%w: complex systems must be square", linalg.ErrDimension))
+	}
+	res.N = n
+
+	solveStart := time.Now()
+	m, det, err := linalg.Eliminate(matrix)
+	res.ComplexDeterminant = (*cliComplex)(&det)
+	if err != nil {
+		return fail(exitCode(err), err)
+	}
+	res.ComplexTriangular = m
+	for c := 0; c < len(matrix[0])-n; c++ {
+		res.ComplexSolutions = append(res.ComplexSolutions, linalg.BackSubstitute(m, c))
+	}
+	res.Timings.Solve = milliseconds(time.Since(solveStart))
+
+	a := matrixColumns(matrix, n)
+	for c, x := range res.ComplexSolutions {
+		rhs := make([]complex128, n)
+		for i := range matrix {
+			rhs[i] = matrix[i][n+c]
+		}
+		deltas := linalg.Residual(linalg.Augment(a, rhs), x)
+		moduli := make([]float64, n)
+		for i := range deltas {
+			moduli[i] = cmplx.Abs(deltas[i])
+		}
+		res.Residuals = append(res.Residuals, moduli)
+		for _, z := range x {
+			if cmplx.IsInf(z) || cmplx.IsNaN(z) {
+				return fail(exitSingular, fmt.Errorf("%w: solution %d contains infinite or NaN values", linalg.ErrSingular, c+1))
+			}
+		}
+	}
+	res.Timings.Total = milliseconds(time.Since(start))
+	return res
+}
+
+// exitCode код завершения по типу ошибки решателя
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, linalg.ErrParse), errors.Is(err, linalg.ErrDimension), errors.Is(err, linalg.ErrIO):
+		return exitInput
+	case errors.Is(err, linalg.ErrSingular), errors.Is(err, linalg.ErrNotPositiveDefinite):
+		return exitSingular
+	case errors.Is(err, linalg.ErrNoConvergence):
+		return exitNoConvergence
+	default:
+		// неверные параметры метода
+		return exitUsage
+	}
+}
+
+// writeCLIText выводит результат в текстовом виде
+func writeCLIText(w io.Writer, res CLIResult) error {
+	if res.Error != "" && res.N == 0 {
+		_, err := fmt.Fprintln(w, "Ошибка:", res.Error)
+		return err
+	}
+	fmt.Fprintln(w, "Файл:", res.Input)
+	fmt.Fprintln(w, "Метод:", res.Method)
+	if res.Determinant != nil {
+		fmt.Fprintln(w, "Определитель матрицы:", *res.Determinant)
+	}
+	if res.ComplexDeterminant != nil {
+		fmt.Fprintln(w, "Определитель матрицы:", formatComplex(complex128(*res.ComplexDeterminant)))
+	}
+	if res.Triangular != nil {
+		printMatrix(w, "Треугольная матрица", linalg.Matrix(res.Triangular))
+	}
+	if res.ComplexTriangular != nil && res.N <= linalg.MaxPrintDimension {
+		fmt.Fprintln(w, "Треугольная матрица:")
+		for _, row := range res.ComplexTriangular {
+			fmt.Fprintln(w, formatComplexVector(row))
+		}
+	}
+	if res.Trace != "" {
+		fmt.Fprintln(w, "Трасса преобразований сохранена в файл", res.Trace)
+	}
+	for c := range res.Solutions {
+		fmt.Fprintf(w, "Правая часть №%d\n", c+1)
+		if c < len(res.Iterations) {
+			fmt.Fprintln(w, "Количество итераций:", res.Iterations[c])
+		}
+		fmt.Fprintln(w, "Решения: ", res.Solutions[c])
+		fmt.Fprintln(w, "Невязки: ", res.Residuals[c])
+	}
+	for c := range res.ComplexSolutions {
+		fmt.Fprintf(w, "Правая часть №%d\n", c+1)
+		fmt.Fprintln(w, "Решения: ", formatComplexVector(res.ComplexSolutions[c]))
+		if c < len(res.Residuals) {
+			fmt.Fprintln(w, "Модули невязок: ", res.Residuals[c])
+		}
+	}
+	if res.Error != "" {
+		fmt.Fprintln(w, "Ошибка:", res.Error)
+	}
+	_, err := fmt.Fprintf(w, "Время: чтение %.3f мс, решение %.3f мс, всего %.3f мс\n",
+		res.Timings.Read, res.Timings.Solve, res.Timings.Total)
+	return err
+}
+
+// formatComplexVector комплексные числа через пробел
+func formatComplexVector(x []complex128) string {
+	parts := make([]string, len(x))
+	for i, z := range x {
+		parts[i] = formatComplex(z)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// finite возвращает указатель на число или nil для бесконечности и NaN,
+// которые нельзя записать в JSON
+func finite(x float64) *float64 {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return nil
+	}
+	return &x
+}
+
+// milliseconds длительность в миллисекундах
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"math/cmplx"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCLIComplex(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		args  []string
+		code  int
+		// want решения по правым частям, nil - решение не проверяется
+		want [][]complex128
+	}{
+		{
+			name:  "one right side",
+			input: "2\n1+2i 3 5+2i\n1 -1i 1-1i\n",
+			code:  exitOK,
+			want:  [][]complex128{{1.5 + 0.5i, 1.5 - 0.5i}},
+		},
+		{
+			name:  "two right sides",
+			input: "2\n2i 0 2i 4\n0 1+1i 2 1-1i\n",
+			code:  exitOK,
+			want:  [][]complex128{{1, 1 - 1i}, {-2i, -1i}},
+		},
+		{
+			name:  "singular",
+			input: "2\n1i 2i 1\n2i 4i 1\n",
+			code:  exitSingular,
+		},
+		{
+			name:  "method without complex support",
+			input: "2\n1+2i 3 5+2i\n1 -1i 1-1i\n",
+			args:  []string{"-method", "lu"},
+			code:  exitUsage,
+		},
+		{
+			name:  "invalid complex number",
+			input: "2\n1+2i 3 5+2j\n1 -1i 1-1i\n",
+			code:  exitInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			in, out := filepath.Join(dir, "system.txt"), filepath.Join(dir, "result.json")
+			if err := os.WriteFile(in, []byte(tt.input), 0644); err != nil {
+				t.Fatal(err)
+			}
+			args := append([]string{"-in", in, "-format", "json", "-out", out}, tt.args...)
+			if code := RunCLI(args); code != tt.code {
+				t.Fatalf("RunCLI(%v) = %d, want %d", args, code, tt.code)
+			}
+			if tt.code == exitUsage {
+				return
+			}
+
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var res struct {
+				Solutions [][][2]float64 `json:"complex_solutions"`
+				Residuals [][]float64    `json:"residuals"`
+				ExitCode  int            `json:"exit_code"`
+			}
+			if err := json.Unmarshal(data, &res); err != nil {
+				t.Fatalf("invalid JSON %s: %v", data, err)
+			}
+			if res.ExitCode != tt.code {
+				t.Errorf("exit_code = %d, want %d", res.ExitCode, tt.code)
+			}
+			if tt.want == nil {
+				return
+			}
+			if len(res.Solutions) != len(tt.want) || len(res.Residuals) != len(tt.want) {
+				t.Fatalf("got %d solutions and %d residuals, want %d", len(res.Solutions), len(res.Residuals), len(tt.want))
+			}
+			for c := range tt.want {
+				for i, w := range tt.want[c] {
+					got := complex(res.Solutions[c][i][0], res.Solutions[c][i][1])
+					if cmplx.Abs(got-w) > 1e-12 {
+						t.Errorf("right side %d: x%d = %v, want %v", c+1, i+1, got, w)
+					}
+				}
+				for i, r := range res.Residuals[c] {
+					if math.Abs(r) > 1e-12 {
+						t.Errorf("right side %d: residual %d = %v", c+1, i+1, r)
+					}
+				}
+			}
+		})
+	}
+}
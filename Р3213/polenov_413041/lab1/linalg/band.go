@@ -0,0 +1,202 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// BandMatrix ленточная матрица n×n с P поддиагоналями и Q наддиагоналями.
+// Строка i хранит элементы столбцов i-P..i+Q, элемент (i, j) лежит в Data[i][j-i+P]
+type BandMatrix struct {
+	N, P, Q int
+	Data    [][]float64
+}
+
+// NewBandMatrix создает нулевую ленточную матрицу
+func NewBandMatrix(n, p, q int) *BandMatrix {
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = make([]float64, p+q+1)
+	}
+	return &BandMatrix{N: n, P: p, Q: q, Data: data}
+}
+
+// At элемент (i, j), вне ленты - ноль
+func (bm *BandMatrix) At(i, j int) float64 {
+	if j < i-bm.P || j > i+bm.Q {
+		return 0
+	}
+	return bm.Data[i][j-i+bm.P]
+}
+
+// Set записывает элемент (i, j) внутри ленты
+func (bm *BandMatrix) Set(i, j int, v float64) {
+	bm.Data[i][j-i+bm.P] = v
+}
+
+// Diagonal возвращает диагональ со смещением offset (отрицательное - поддиагональ)
+func (bm *BandMatrix) Diagonal(offset int) Vector {
+	var d []float64
+	for i := max(0, -offset); i < bm.N && i+offset < bm.N; i++ {
+		d = append(d, bm.At(i, i+offset))
+	}
+	return d
+}
+
+// MulVec произведение матрицы на вектор
+func (bm *BandMatrix) MulVec(x Vector) Vector {
+	y := make([]float64, bm.N)
+	for i := 0; i < bm.N; i++ {
+		for j := max(0, i-bm.P); j <= min(bm.N-1, i+bm.Q); j++ {
+			y[i] += bm.At(i, j) * x[j]
+		}
+	}
+	return y
+}
+
+// Residual вектор невязок b - Ax
+func (bm *BandMatrix) Residual(x Vector, b Vector) Vector {
+	ax := bm.MulVec(x)
+	for i := range ax {
+		ax[i] = b[i] - ax[i]
+	}
+	return ax
+}
+
+// IsTridiagonalStable проверяет условие устойчивости метода прогонки:
+// |b_i| >= |a_i| + |c_i| во всех строках и строгое неравенство хотя бы в одной
+func IsTridiagonalStable(sub, diag, sup Vector) bool {
+	n := len(diag)
+	strict := false
+	for i := 0; i < n; i++ {
+		side := 0.0
+		if i > 0 {
+			side += math.Abs(sub[i-1])
+		}
+		if i < n-1 {
+			side += math.Abs(sup[i])
+		}
+		if math.Abs(diag[i]) < side {
+			return false
+		}
+		if math.Abs(diag[i]) > side {
+			strict = true
+		}
+	}
+	return strict
+}
+
+// SolveTridiagonal метод прогонки (алгоритм Томаса) для трехдиагональной системы.
+// sub - поддиагональ (n-1), diag - главная диагональ (n), sup - наддиагональ (n-1)
+func SolveTridiagonal(sub, diag, sup, rhs Vector) (Vector, error) {
+	n := len(diag)
+	if len(sub) != n-1 || len(sup) != n-1 || len(rhs) != n {
+		return nil, fmt.Errorf("%w: tridiagonal system has inconsistent sizes", ErrDimension)
+	}
+
+	// прямой ход: прогоночные коэффициенты
+	alpha := make([]float64, n)
+	beta := make([]float64, n)
+	for i := 0; i < n; i++ {
+		denom := diag[i]
+		if i > 0 {
+			denom += sub[i-1] * alpha[i-1]
+		}
+		if denom == 0 {
+			return nil, fmt.Errorf("%w: zero denominator in Thomas algorithm at row %d", ErrSingular, i+1)
+		}
+		if i < n-1 {
+			alpha[i] = -sup[i] / denom
+		}
+		beta[i] = rhs[i]
+		if i > 0 {
+			beta[i] -= sub[i-1] * beta[i-1]
+		}
+		beta[i] /= denom
+	}
+
+	// обратный ход
+	x := make([]float64, n)
+	x[n-1] = beta[n-1]
+	for i := n - 2; i >= 0; i-- {
+		x[i] = alpha[i]*x[i+1] + beta[i]
+	}
+	return x, nil
+}
+
+// BandLU LU-разложение ленточной матрицы с частичным выбором главного элемента.
+// Из-за перестановок строк ширина верхней ленты U растет до P+Q
+type BandLU struct {
+	n, p, q int
+	// w[i] - строка i, элемент столбца j лежит в w[i][j-i+p]
+	w [][]float64
+	// l[k][i] - множитель для строки k+i+1 на шаге k
+	l   [][]float64
+	piv []int
+}
+
+// NewBandLU строит разложение ленточной матрицы
+func NewBandLU(bm *BandMatrix) (*BandLU, error) {
+	n, p, q := bm.N, bm.P, bm.Q
+	f := &BandLU{n: n, p: p, q: q, w: make([][]float64, n), l: make([][]float64, n), piv: make([]int, n)}
+	for i := 0; i < n; i++ {
+		f.w[i] = make([]float64, 2*p+q+1)
+		copy(f.w[i], bm.Data[i])
+	}
+
+	for k := 0; k < n; k++ {
+		last := min(n-1, k+p)
+		lastCol := min(n-1, k+p+q)
+
+		row := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(f.w[i][k-i+p]) > math.Abs(f.w[row][k-row+p]) {
+				row = i
+			}
+		}
+		if f.w[row][k-row+p] == 0 {
+			return nil, fmt.Errorf("%w, zero pivot in column %d", ErrSingular, k+1)
+		}
+		f.piv[k] = row
+		if row != k {
+			for j := k; j <= lastCol; j++ {
+				f.w[k][j-k+p], f.w[row][j-row+p] = f.w[row][j-row+p], f.w[k][j-k+p]
+			}
+		}
+
+		f.l[k] = make([]float64, last-k)
+		pivot := f.w[k][p]
+		for i := k + 1; i <= last; i++ {
+			factor := f.w[i][k-i+p] / pivot
+			f.l[k][i-k-1] = factor
+			if factor == 0 {
+				continue
+			}
+			for j := k; j <= lastCol; j++ {
+				f.w[i][j-i+p] -= factor * f.w[k][j-k+p]
+			}
+		}
+	}
+	return f, nil
+}
+
+// Solve решает систему с разложенной ленточной матрицей
+func (f *BandLU) Solve(b Vector) Vector {
+	y := append([]float64{}, b...)
+	for k := 0; k < f.n; k++ {
+		y[k], y[f.piv[k]] = y[f.piv[k]], y[k]
+		for i, factor := range f.l[k] {
+			y[k+i+1] -= factor * y[k]
+		}
+	}
+
+	x := make([]float64, f.n)
+	for i := f.n - 1; i >= 0; i-- {
+		sum := y[i]
+		for j := i + 1; j <= min(f.n-1, i+f.p+f.q); j++ {
+			sum -= f.w[i][j-i+f.p] * x[j]
+		}
+		x[i] = sum / f.w[i][f.p]
+	}
+	return x
+}
@@ -0,0 +1,97 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// determinantTolerance допустимая относительная погрешность определителя
+// в float64 по сравнению с точным значением
+const determinantTolerance = 1e-9
+
+// IsIntegerMatrix проверяет, что все коэффициенты матрицы - целые числа,
+// точно представимые в float64
+func IsIntegerMatrix(a Matrix) bool {
+	for i := range a {
+		for _, v := range a[i] {
+			if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MaxExactDimension максимальный порядок матрицы, для которого точный
+// определитель проверяется автоматически: длина чисел растет вместе с
+// порядком, и уже при n = 200 вычисление занимает секунды
+const MaxExactDimension = 50
+
+// BareissDeterminant вычисляет определитель целочисленной матрицы точно
+// методом Барейса: все промежуточные деления выполняются нацело,
+// поэтому дроби не возникают
+func BareissDeterminant(a Matrix) (*big.Int, error) {
+	if !IsIntegerMatrix(a) {
+		return nil, fmt.Errorf("%w: matrix isn't integer", ErrInvalidArgument)
+	}
+	n := len(a)
+	m := make([][]*big.Int, n)
+	for i := range a {
+		if len(a[i]) != n {
+			return nil, fmt.Errorf("%w: matrix isn't square", ErrDimension)
+		}
+		m[i] = make([]*big.Int, n)
+		for j := range a[i] {
+			m[i][j] = big.NewInt(int64(a[i][j]))
+		}
+	}
+
+	sign := 1
+	prev := big.NewInt(1)
+	tmp := new(big.Int)
+	for k := 0; k < n-1; k++ {
+		if m[k][k].Sign() == 0 {
+			swapped := false
+			for i := k + 1; i < n; i++ {
+				if m[i][k].Sign() != 0 {
+					m[k], m[i] = m[i], m[k]
+					sign = -sign
+					swapped = true
+					break
+				}
+			}
+			if !swapped {
+				return new(big.Int), nil
+			}
+		}
+
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				// m[i][j] = (m[i][j]*m[k][k] - m[i][k]*m[k][j]) / prev
+				m[i][j].Mul(m[i][j], m[k][k])
+				m[i][j].Sub(m[i][j], tmp.Mul(m[i][k], m[k][j]))
+				m[i][j].Quo(m[i][j], prev)
+			}
+		}
+		prev = m[k][k]
+	}
+
+	det := new(big.Int).Set(m[n-1][n-1])
+	if sign < 0 {
+		det.Neg(det)
+	}
+	return det, nil
+}
+
+// CompareDeterminant возвращает относительное расхождение определителя
+// в float64 с точным значением и признак того, что оно в пределах допуска
+func CompareDeterminant(exact *big.Int, approx float64) (float64, bool) {
+	if exact.Sign() == 0 {
+		return math.Abs(approx), math.Abs(approx) <= determinantTolerance
+	}
+	e := new(big.Float).SetInt(exact)
+	diff := new(big.Float).Sub(new(big.Float).SetFloat64(approx), e)
+	rel, _ := new(big.Float).Quo(diff.Abs(diff), e.Abs(e)).Float64()
+	return rel, rel <= determinantTolerance
+}
@@ -0,0 +1,183 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// symmetryTolerance допустимое относительное различие a_ij и a_ji
+const symmetryTolerance = 1e-12
+
+// ldltPivotTolerance минимальный элемент D относительно наибольшего элемента
+// матрицы: без перестановок малый элемент D приводит к росту элементов L
+// и потере точности, такую матрицу лучше решать LU-разложением
+const ldltPivotTolerance = 1e-8
+
+// IsSymmetric проверяет симметричность квадратной матрицы
+func IsSymmetric(a Matrix) bool {
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			scale := math.Max(math.Max(math.Abs(a[i][j]), math.Abs(a[j][i])), 1)
+			if math.Abs(a[i][j]-a[j][i]) > symmetryTolerance*scale {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Cholesky разложение A = LLᵀ симметричной положительно определенной матрицы
+type Cholesky struct {
+	L [][]float64
+}
+
+// NewCholesky строит разложение Холецкого, используя только нижний
+// треугольник матрицы. Ошибка означает, что матрица не положительно определена
+func NewCholesky(a Matrix) (*Cholesky, error) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+
+	for j := 0; j < n; j++ {
+		sum := a[j][j]
+		for k := 0; k < j; k++ {
+			sum -= l[j][k] * l[j][k]
+		}
+		if sum <= 0 {
+			return nil, fmt.Errorf("%w: nonpositive pivot at column %d", ErrNotPositiveDefinite, j+1)
+		}
+		l[j][j] = math.Sqrt(sum)
+
+		for i := j + 1; i < n; i++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			l[i][j] = sum / l[j][j]
+		}
+	}
+	return &Cholesky{L: l}, nil
+}
+
+// Solve решает Ly = b и Lᵀx = y
+func (c *Cholesky) Solve(b Vector) Vector {
+	n := len(c.L)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= c.L[i][k] * y[k]
+		}
+		y[i] = sum / c.L[i][i]
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < n; k++ {
+			sum -= c.L[k][i] * x[k]
+		}
+		x[i] = sum / c.L[i][i]
+	}
+	return x
+}
+
+// Determinant квадрат произведения диагонали L
+func (c *Cholesky) Determinant() float64 {
+	det := 1.0
+	for i := range c.L {
+		det *= c.L[i][i]
+	}
+	return det * det
+}
+
+// FactorSymmetric разложение симметричной матрицы: Холецкого, если она
+// положительно определена, иначе LDLᵀ
+func FactorSymmetric(a Matrix) (Factorization, error) {
+	if chol, err := NewCholesky(a); err == nil {
+		return chol, nil
+	}
+	return NewLDLT(a)
+}
+
+// LDLT разложение A = LDLᵀ симметричной (в том числе знаконеопределенной)
+// матрицы, L - нижняя унитреугольная, D - диагональная
+type LDLT struct {
+	L [][]float64
+	D []float64
+}
+
+// NewLDLT строит разложение без перестановок, ошибка возвращается,
+// если элемент D мал относительно элементов матрицы
+func NewLDLT(a Matrix) (*LDLT, error) {
+	n := len(a)
+	scale := 0.0
+	for i := range a {
+		for j := 0; j < n; j++ {
+			scale = math.Max(scale, math.Abs(a[i][j]))
+		}
+	}
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		l[i][i] = 1
+	}
+	d := make([]float64, n)
+
+	for j := 0; j < n; j++ {
+		sum := a[j][j]
+		for k := 0; k < j; k++ {
+			sum -= l[j][k] * l[j][k] * d[k]
+		}
+		if math.Abs(sum) <= ldltPivotTolerance*scale {
+			return nil, fmt.Errorf("%w: small pivot %g in LDLᵀ factorization at column %d", ErrSingular, sum, j+1)
+		}
+		d[j] = sum
+
+		for i := j + 1; i < n; i++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k] * d[k]
+			}
+			l[i][j] = sum / d[j]
+		}
+	}
+	return &LDLT{L: l, D: d}, nil
+}
+
+// Solve решает Lz = b, Dy = z и Lᵀx = y
+func (f *LDLT) Solve(b Vector) Vector {
+	n := len(f.L)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= f.L[i][k] * y[k]
+		}
+		y[i] = sum
+	}
+	for i := range y {
+		y[i] /= f.D[i]
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < n; k++ {
+			sum -= f.L[k][i] * x[k]
+		}
+		x[i] = sum
+	}
+	return x
+}
+
+// Determinant произведение диагонали D
+func (f *LDLT) Determinant() float64 {
+	det := 1.0
+	for _, v := range f.D {
+		det *= v
+	}
+	return det
+}
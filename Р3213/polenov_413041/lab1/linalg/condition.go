@@ -0,0 +1,140 @@
+package linalg
+
+import "math"
+
+// ConditionNumbers числа обусловленности матрицы в разных нормах
+type ConditionNumbers struct {
+	Cond1   float64
+	Cond2   float64 // оценка степенным методом
+	CondInf float64
+}
+
+// Inverse вычисляет обратную матрицу через LU-разложение,
+// решая систему для каждого столбца единичной матрицы
+func Inverse(a Matrix) (Matrix, error) {
+	lu, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return FactorizationInverse(lu, len(a)), nil
+}
+
+// FactorizationInverse обратная матрица порядка n по готовому разложению
+func FactorizationInverse(f Factorization, n int) Matrix {
+	inv := make([][]float64, n)
+	for i := range inv {
+		inv[i] = make([]float64, n)
+	}
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		col := f.Solve(e)
+		e[j] = 0
+		for i := 0; i < n; i++ {
+			inv[i][j] = col[i]
+		}
+	}
+	return inv
+}
+
+// Norm1 максимальная сумма модулей по столбцам
+func Norm1(a Matrix) float64 {
+	norm := 0.0
+	for j := range a[0] {
+		sum := 0.0
+		for i := range a {
+			sum += math.Abs(a[i][j])
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+// NormInf максимальная сумма модулей по строкам
+func NormInf(a Matrix) float64 {
+	norm := 0.0
+	for i := range a {
+		sum := 0.0
+		for _, v := range a[i] {
+			sum += math.Abs(v)
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+// Norm2Estimate оценка спектральной нормы как корня из наибольшего
+// собственного значения AᵀA, найденного степенным методом
+func Norm2Estimate(a Matrix) float64 {
+	n := len(a[0])
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(n))
+	}
+
+	lambda := 0.0
+	for k := 0; k < 100; k++ {
+		// w = Aᵀ(Av)
+		av := make([]float64, len(a))
+		for i := range a {
+			for j := range a[i] {
+				av[i] += a[i][j] * v[j]
+			}
+		}
+		w := make([]float64, n)
+		for i := range a {
+			for j := range a[i] {
+				w[j] += a[i][j] * av[i]
+			}
+		}
+
+		norm := VectorNorm(w)
+		if norm == 0 {
+			return 0
+		}
+		converged := math.Abs(norm-lambda) <= 1e-10*norm
+		lambda = norm
+		for j := range w {
+			v[j] = w[j] / norm
+		}
+		if converged {
+			break
+		}
+	}
+	return math.Sqrt(lambda)
+}
+
+// Conditioning вычисляет обратную матрицу и числа обусловленности
+func Conditioning(a Matrix) (Matrix, ConditionNumbers, error) {
+	inv, err := Inverse(a)
+	if err != nil {
+		return nil, ConditionNumbers{}, err
+	}
+	return inv, conditionNumbers(a, inv), nil
+}
+
+// FactorizationConditioning то же, что Conditioning, но обратная матрица
+// строится по готовому разложению f без повторного LU-разложения
+func FactorizationConditioning(a Matrix, f Factorization) (Matrix, ConditionNumbers) {
+	inv := FactorizationInverse(f, len(a))
+	return inv, conditionNumbers(a, inv)
+}
+
+func conditionNumbers(a Matrix, inv Matrix) ConditionNumbers {
+	return ConditionNumbers{
+		Cond1:   Norm1(a) * Norm1(inv),
+		Cond2:   Norm2Estimate(a) * Norm2Estimate(inv),
+		CondInf: NormInf(a) * NormInf(inv),
+	}
+}
+
+// Meaningless число обусловленности настолько велико, что ошибки округления
+// сравнимы с самим решением
+func (c ConditionNumbers) Meaningless() bool {
+	return math.Max(c.Cond1, c.CondInf)*machineEpsilon >= 1
+}
+
+// LostDigits примерное число теряемых значащих десятичных цифр
+func (c ConditionNumbers) LostDigits() float64 {
+	return math.Log10(math.Max(c.Cond1, c.CondInf))
+}
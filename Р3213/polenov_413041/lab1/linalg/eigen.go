@@ -0,0 +1,471 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// maxQRSweeps максимальное число QR-итераций на одно собственное значение
+const maxQRSweeps = 30
+
+// EigenResult собственное значение, найденное степенным методом
+// или методом обратных итераций
+type EigenResult struct {
+	Value  float64
+	Vector []float64
+	// Iterations число выполненных итераций
+	Iterations int
+	// Residual невязка ||Av - λv|| для нормированного вектора v
+	Residual float64
+}
+
+// EigenPair собственная пара, найденная QR-алгоритмом
+type EigenPair struct {
+	Value  complex128
+	Vector []complex128
+	// Residual невязка ||Av - λv|| для нормированного вектора v
+	Residual float64
+}
+
+// PowerIteration степенной метод для матрицы A - shift*I: находит
+// собственное значение A, наиболее удаленное от shift. Значение уточняется
+// отношением Рэлея, итерации прекращаются, когда невязка ||Av - λv||
+// становится меньше eps (относительно |λ|)
+func PowerIteration(a Matrix, shift float64, eps float64, maxIter int) (EigenResult, error) {
+	n := len(a)
+	v := startVector(n)
+	res := EigenResult{}
+
+	for res.Iterations < maxIter {
+		next := matVec(a, v)
+		for i := range next {
+			next[i] -= shift * v[i]
+		}
+		norm := VectorNorm(next)
+		if norm == 0 {
+			// v лежит в ядре A - shift*I
+			res.Value = shift
+			break
+		}
+		for i := range next {
+			next[i] /= norm
+		}
+		v = next
+		res.Iterations++
+
+		res.Value = rayleighQuotient(a, v)
+		res.Residual = eigenResidual(a, res.Value, v)
+		if res.Residual < eps*math.Max(1, math.Abs(res.Value)) {
+			res.Vector = v
+			return res, nil
+		}
+	}
+	res.Vector = v
+	if res.Iterations == maxIter {
+		return res, fmt.Errorf("power iteration: %w in %d iterations", ErrNoConvergence, maxIter)
+	}
+	return res, nil
+}
+
+// InverseIteration метод обратных итераций со сдвигом: находит собственное
+// значение A, ближайшее к shift. Матрица A - shift*I раскладывается один раз,
+// на каждой итерации решается система с готовым LU-разложением
+func InverseIteration(a Matrix, shift float64, eps float64, maxIter int) (EigenResult, error) {
+	n := len(a)
+	shifted := make([][]float64, n)
+	for i := range a {
+		shifted[i] = append([]float64{}, a[i][:n]...)
+		shifted[i][i] -= shift
+	}
+	lu, err := NewLU(shifted)
+	if err != nil || IsRankDeficient(shifted) {
+		// сдвиг совпал с собственным значением, немного смещаем его
+		delta := math.Max(math.Abs(shift), 1) * 1e-10
+		for i := range shifted {
+			shifted[i][i] -= delta
+		}
+		lu, err = NewLU(shifted)
+		if err != nil {
+			return EigenResult{}, err
+		}
+	}
+
+	v := startVector(n)
+	res := EigenResult{}
+
+	for res.Iterations < maxIter {
+		next := lu.Solve(v)
+		norm := VectorNorm(next)
+		for i := range next {
+			next[i] /= norm
+		}
+		v = next
+		res.Iterations++
+
+		res.Value = rayleighQuotient(a, v)
+		res.Residual = eigenResidual(a, res.Value, v)
+		if res.Residual < eps*math.Max(1, math.Abs(res.Value)) {
+			res.Vector = v
+			return res, nil
+		}
+	}
+	res.Vector = v
+	return res, fmt.Errorf("inverse iteration: %w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// startVector начальное приближение с ненулевыми и неравными компонентами,
+// чтобы оно не оказалось ортогональным искомому собственному вектору
+func startVector(n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1 + float64(i)/float64(n)
+	}
+	norm := VectorNorm(v)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// matVec произведение квадратной матрицы на вектор
+func matVec(a [][]float64, v []float64) []float64 {
+	r := make([]float64, len(v))
+	for i := range r {
+		for j := range v {
+			r[i] += a[i][j] * v[j]
+		}
+	}
+	return r
+}
+
+// rayleighQuotient отношение Рэлея vᵀAv / vᵀv
+func rayleighQuotient(a [][]float64, v []float64) float64 {
+	av := matVec(a, v)
+	return dot(v, av) / dot(v, v)
+}
+
+// eigenResidual невязка ||Av - λv|| для вектора v единичной длины
+func eigenResidual(a [][]float64, lambda float64, v []float64) float64 {
+	av := matVec(a, v)
+	for i := range av {
+		av[i] -= lambda * v[i]
+	}
+	return VectorNorm(av) / VectorNorm(v)
+}
+
+// Hessenberg приводит матрицу к верхней форме Хессенберга преобразованиями
+// отражения H = QᵀAQ, сохраняющими собственные значения
+func Hessenberg(a Matrix) Matrix {
+	n := len(a)
+	h := make([][]float64, n)
+	for i := range a {
+		h[i] = append([]float64{}, a[i][:n]...)
+	}
+
+	for k := 0; k < n-2; k++ {
+		v := make([]float64, n-k-1)
+		for i := range v {
+			v[i] = h[k+1+i][k]
+		}
+		norm := VectorNorm(v)
+		if norm == 0 {
+			continue
+		}
+		alpha := -math.Copysign(norm, v[0])
+		v[0] -= alpha
+		vv := dot(v, v)
+
+		// H = (I - 2vvᵀ/vᵀv) H (I - 2vvᵀ/vᵀv)
+		for j := k; j < n; j++ {
+			s := 0.0
+			for i := range v {
+				s += v[i] * h[k+1+i][j]
+			}
+			s = 2 * s / vv
+			for i := range v {
+				h[k+1+i][j] -= s * v[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			s := 0.0
+			for j := range v {
+				s += h[i][k+1+j] * v[j]
+			}
+			s = 2 * s / vv
+			for j := range v {
+				h[i][k+1+j] -= s * v[j]
+			}
+		}
+
+		h[k+1][k] = alpha
+		for i := k + 2; i < n; i++ {
+			h[i][k] = 0
+		}
+	}
+	return h
+}
+
+// EigenvaluesQR находит все собственные значения матрицы QR-алгоритмом
+// с двойным сдвигом Фрэнсиса на форме Хессенберга. Комплексно-сопряженные
+// пары выделяются как блоки 2×2
+func EigenvaluesQR(a Matrix) ([]complex128, error) {
+	h := Hessenberg(a)
+	n := len(h)
+	values := make([]complex128, n)
+
+	anorm := 0.0
+	for i := range h {
+		for j := max(i-1, 0); j < n; j++ {
+			anorm += math.Abs(h[i][j])
+		}
+	}
+
+	nn := n - 1
+	// накопленный исключительный сдвиг
+	t := 0.0
+	for nn >= 0 {
+		its := 0
+		for {
+			// поиск малого поддиагонального элемента для разделения задачи
+			l := nn
+			for ; l >= 1; l-- {
+				s := math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+				if s == 0 {
+					s = anorm
+				}
+				if math.Abs(h[l][l-1])+s == s {
+					h[l][l-1] = 0
+					break
+				}
+			}
+
+			x := h[nn][nn]
+			if l == nn {
+				// отделилось одно вещественное значение
+				values[nn] = complex(x+t, 0)
+				nn--
+				break
+			}
+			y := h[nn-1][nn-1]
+			w := h[nn][nn-1] * h[nn-1][nn]
+			if l == nn-1 {
+				// отделился блок 2×2: пара вещественных или комплексных значений
+				p := 0.5 * (y - x)
+				q := p*p + w
+				z := math.Sqrt(math.Abs(q))
+				x += t
+				if q >= 0 {
+					z = p + math.Copysign(z, p)
+					values[nn-1] = complex(x+z, 0)
+					values[nn] = values[nn-1]
+					if z != 0 {
+						values[nn] = complex(x-w/z, 0)
+					}
+				} else {
+					values[nn-1] = complex(x+p, z)
+					values[nn] = complex(x+p, -z)
+				}
+				nn -= 2
+				break
+			}
+
+			if its == maxQRSweeps {
+				return nil, fmt.Errorf("QR algorithm: %w in %d iterations", ErrNoConvergence, maxQRSweeps)
+			}
+			if its == 10 || its == 20 {
+				// исключительный сдвиг для выхода из зацикливания
+				t += x
+				for i := 0; i <= nn; i++ {
+					h[i][i] -= x
+				}
+				s := math.Abs(h[nn][nn-1]) + math.Abs(h[nn-1][nn-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+			its++
+			francisStep(h, l, nn, x, y, w)
+		}
+	}
+	return values, nil
+}
+
+// francisStep один шаг QR-алгоритма с двойным неявным сдвигом на активном
+// блоке h[l..nn][l..nn]. x, y - последние диагональные элементы, w -
+// произведение элементов вокруг них
+func francisStep(h [][]float64, l int, nn int, x float64, y float64, w float64) {
+	var p, q, r, z float64
+	// поиск двух последовательных малых поддиагональных элементов
+	m := nn - 2
+	for ; m >= l; m-- {
+		z = h[m][m]
+		r = x - z
+		s := y - z
+		p = (r*s-w)/h[m+1][m] + h[m][m+1]
+		q = h[m+1][m+1] - z - r - s
+		r = h[m+2][m+1]
+		s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+		p /= s
+		q /= s
+		r /= s
+		if m == l {
+			break
+		}
+		u := math.Abs(h[m][m-1]) * (math.Abs(q) + math.Abs(r))
+		v := math.Abs(p) * (math.Abs(h[m-1][m-1]) + math.Abs(z) + math.Abs(h[m+1][m+1]))
+		if u+v == v {
+			break
+		}
+	}
+	for i := m + 2; i <= nn; i++ {
+		h[i][i-2] = 0
+		if i != m+2 {
+			h[i][i-3] = 0
+		}
+	}
+
+	// преследование выпуклости отражениями 3×3
+	for k := m; k <= nn-1; k++ {
+		if k != m {
+			p = h[k][k-1]
+			q = h[k+1][k-1]
+			r = 0
+			if k != nn-1 {
+				r = h[k+2][k-1]
+			}
+			x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+			if x != 0 {
+				p /= x
+				q /= x
+				r /= x
+			}
+		}
+		s := math.Copysign(math.Sqrt(p*p+q*q+r*r), p)
+		if s == 0 {
+			continue
+		}
+		if k == m {
+			if l != m {
+				h[k][k-1] = -h[k][k-1]
+			}
+		} else {
+			h[k][k-1] = -s * x
+		}
+		p += s
+		x = p / s
+		y = q / s
+		z = r / s
+		q /= p
+		r /= p
+		for j := k; j <= nn; j++ {
+			p = h[k][j] + q*h[k+1][j]
+			if k != nn-1 {
+				p += r * h[k+2][j]
+				h[k+2][j] -= p * z
+			}
+			h[k+1][j] -= p * y
+			h[k][j] -= p * x
+		}
+		for i := l; i <= min(nn, k+3); i++ {
+			p = x*h[i][k] + y*h[i][k+1]
+			if k != nn-1 {
+				p += z * h[i][k+2]
+				h[i][k+2] -= p * r
+			}
+			h[i][k+1] -= p * q
+			h[i][k] -= p
+		}
+	}
+}
+
+// EigenDecomposition находит все собственные значения QR-алгоритмом
+// и соответствующие им векторы обратными итерациями в комплексной арифметике
+func EigenDecomposition(a Matrix) ([]EigenPair, error) {
+	values, err := EigenvaluesQR(a)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]EigenPair, len(values))
+	for k, lambda := range values {
+		v, err := complexEigenvector(a, lambda)
+		if err != nil {
+			return nil, err
+		}
+		pairs[k] = EigenPair{Value: lambda, Vector: v, Residual: complexEigenResidual(a, lambda, v)}
+	}
+	return pairs, nil
+}
+
+// complexEigenvector собственный вектор для найденного значения lambda:
+// несколько шагов обратной итерации с матрицей A - μI, где μ немного
+// смещено от lambda, чтобы система оставалась невырожденной
+func complexEigenvector(a [][]float64, lambda complex128) ([]complex128, error) {
+	n := len(a)
+	delta := complex(math.Max(NormInf(a), 1)*1e-10, 0)
+	m := make([][]complex128, n)
+	for i := range m {
+		m[i] = make([]complex128, n)
+		for j := range m[i] {
+			m[i][j] = complex(a[i][j], 0)
+		}
+		m[i][i] -= lambda + delta
+	}
+
+	v := make([]complex128, n)
+	for i, x := range startVector(n) {
+		v[i] = complex(x, 0)
+	}
+	for step := 0; step < 3; step++ {
+		t, _, err := Eliminate(Augment(m, v))
+		if err != nil {
+			return nil, err
+		}
+		v = normalizeComplex(BackSubstitute(t, 0))
+	}
+	return v, nil
+}
+
+// normalizeComplex делит вектор на компоненту с наибольшим модулем
+// (для вещественного собственного значения вектор становится вещественным)
+// и приводит его к единичной евклидовой длине
+func normalizeComplex(v []complex128) []complex128 {
+	big := v[0]
+	for _, x := range v {
+		if cmplx.Abs(x) > cmplx.Abs(big) {
+			big = x
+		}
+	}
+	norm := 0.0
+	r := make([]complex128, len(v))
+	for i := range v {
+		r[i] = v[i] / big
+		norm += real(r[i])*real(r[i]) + imag(r[i])*imag(r[i])
+	}
+	norm = math.Sqrt(norm)
+	for i := range r {
+		// компоненты на уровне ошибок округления считаются нулевыми
+		re, im := real(r[i])/norm, imag(r[i])/norm
+		if math.Abs(re) < machineEpsilon {
+			re = 0
+		}
+		if math.Abs(im) < machineEpsilon {
+			im = 0
+		}
+		r[i] = complex(re, im)
+	}
+	return r
+}
+
+// complexEigenResidual невязка ||Av - λv|| для комплексной собственной пары
+func complexEigenResidual(a [][]float64, lambda complex128, v []complex128) float64 {
+	sum := 0.0
+	for i := range a {
+		r := -lambda * v[i]
+		for j := range v {
+			r += complex(a[i][j], 0) * v[j]
+		}
+		sum += real(r)*real(r) + imag(r)*imag(r)
+	}
+	return math.Sqrt(sum)
+}
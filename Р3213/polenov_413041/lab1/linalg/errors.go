@@ -0,0 +1,40 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ошибки пакета. Функции возвращают их обернутыми через %w с подробностями,
+// проверять тип ошибки следует с помощью errors.Is
+var (
+	// ErrSingular матрица вырождена (или численно вырождена)
+	ErrSingular = errors.New("matrix is singular")
+	// ErrDimension размеры матрицы или вектора не подходят для операции
+	ErrDimension = errors.New("invalid dimensions")
+	// ErrParse ошибка разбора входного файла
+	ErrParse = errors.New("parse error")
+	// ErrNoConvergence итерационный метод не достиг заданной точности
+	ErrNoConvergence = errors.New("method didn't converge")
+	// ErrNotPositiveDefinite матрица не положительно определена
+	ErrNotPositiveDefinite = errors.New("matrix isn't positive definite")
+	// ErrInvalidArgument неверный параметр метода или формата
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrIO ошибка чтения или записи файла
+	ErrIO = errors.New("i/o error")
+)
+
+// parseError ошибка разбора с позицией в файле
+func parseError(pos string, format string, args ...any) error {
+	return fmt.Errorf("%w at %s: %s", ErrParse, pos, fmt.Sprintf(format, args...))
+}
+
+// linePos позиция в текстовом файле
+func linePos(line int, col int) string {
+	return fmt.Sprintf("line %d, column %d", line, col)
+}
+
+// lineNumber номер строки текстового файла
+func lineNumber(line int) string {
+	return fmt.Sprintf("line %d", line)
+}
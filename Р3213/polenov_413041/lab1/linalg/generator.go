@@ -0,0 +1,168 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// MatrixFamily семейство генерируемых матриц
+type MatrixFamily string
+
+const (
+	// FamilyUniform равномерно распределенные числа из [-15, 15] с 4 знаками
+	FamilyUniform MatrixFamily = "u"
+	// FamilyInteger равномерно распределенные целые числа из [-15, 15]
+	FamilyInteger MatrixFamily = "i"
+	// FamilyDominant матрица со строгим диагональным преобладанием
+	FamilyDominant MatrixFamily = "d"
+	// FamilySPD симметричная положительно определенная матрица MᵀM + nI
+	FamilySPD MatrixFamily = "s"
+	// FamilyHilbert матрица Гильберта 1/(i+j+1)
+	FamilyHilbert MatrixFamily = "h"
+	// FamilyVandermonde матрица Вандермонда по случайным узлам из [-1, 1]
+	FamilyVandermonde MatrixFamily = "v"
+	// FamilyTridiagonal трехдиагональная матрица с диагональным преобладанием
+	FamilyTridiagonal MatrixFamily = "t"
+	// FamilyCondition матрица U·diag(σ)·Vᵀ с заданным числом обусловленности
+	FamilyCondition MatrixFamily = "c"
+)
+
+// GenerateMatrix строит матрицу коэффициентов n×n выбранного семейства.
+// cond используется только для FamilyCondition (спектральное число обусловленности)
+func GenerateMatrix(family MatrixFamily, n int, rnd *rand.Rand, cond float64) (Matrix, error) {
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	uniform := func(lo, hi float64) float64 { return lo + rnd.Float64()*(hi-lo) }
+
+	switch family {
+	case FamilyUniform:
+		for i := range a {
+			for j := range a[i] {
+				a[i][j] = math.Round(uniform(-15, 15)*1e4) / 1e4
+			}
+		}
+	case FamilyInteger:
+		for i := range a {
+			for j := range a[i] {
+				a[i][j] = float64(rnd.Intn(31) - 15)
+			}
+		}
+	case FamilyDominant:
+		for i := range a {
+			sum := 0.0
+			for j := range a[i] {
+				if j != i {
+					a[i][j] = float64(rnd.Intn(21) - 10)
+					sum += math.Abs(a[i][j])
+				}
+			}
+			a[i][i] = sum + float64(1+rnd.Intn(10))
+			if rnd.Intn(2) == 0 {
+				a[i][i] = -a[i][i]
+			}
+		}
+	case FamilySPD:
+		m := make([][]float64, n)
+		for i := range m {
+			m[i] = make([]float64, n)
+			for j := range m[i] {
+				m[i][j] = float64(rnd.Intn(11) - 5)
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				for k := 0; k < n; k++ {
+					a[i][j] += m[k][i] * m[k][j]
+				}
+			}
+			a[i][i] += float64(n)
+		}
+	case FamilyHilbert:
+		for i := range a {
+			for j := range a[i] {
+				a[i][j] = 1 / float64(i+j+1)
+			}
+		}
+	case FamilyVandermonde:
+		for i := range a {
+			x := math.Round(uniform(-1, 1)*1e4) / 1e4
+			for j := range a[i] {
+				a[i][j] = math.Pow(x, float64(j))
+			}
+		}
+	case FamilyTridiagonal:
+		for i := range a {
+			side := 0.0
+			if i > 0 {
+				a[i][i-1] = float64(rnd.Intn(11) - 5)
+				side += math.Abs(a[i][i-1])
+			}
+			if i < n-1 {
+				a[i][i+1] = float64(rnd.Intn(11) - 5)
+				side += math.Abs(a[i][i+1])
+			}
+			a[i][i] = side + float64(1+rnd.Intn(5))
+		}
+	case FamilyCondition:
+		if cond < 1 {
+			return nil, fmt.Errorf("%w: condition number must be at least 1, got %v", ErrInvalidArgument, cond)
+		}
+		u, err := randomOrthogonal(n, rnd)
+		if err != nil {
+			return nil, err
+		}
+		v, err := randomOrthogonal(n, rnd)
+		if err != nil {
+			return nil, err
+		}
+		// сингулярные числа в геометрической прогрессии от 1 до 1/cond
+		sigma := make([]float64, n)
+		for k := range sigma {
+			sigma[k] = math.Pow(cond, -float64(k)/float64(n-1))
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				for k := 0; k < n; k++ {
+					a[i][j] += u[i][k] * sigma[k] * v[j][k]
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown matrix family: %v", ErrInvalidArgument, family)
+	}
+	return a, nil
+}
+
+// randomOrthogonal случайная ортогональная матрица как множитель Q
+// QR-разложения матрицы с нормально распределенными элементами
+func randomOrthogonal(n int, rnd *rand.Rand) ([][]float64, error) {
+	g := make([][]float64, n)
+	for i := range g {
+		g[i] = make([]float64, n)
+		for j := range g[i] {
+			g[i][j] = rnd.NormFloat64()
+		}
+	}
+	qr, err := NewQR(g)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Q(), nil
+}
+
+// GenerateSolution случайное целочисленное решение из [-9, 9]
+// и правая часть b = Ax, вычисленная с компенсацией ошибок округления
+func GenerateSolution(a Matrix, rnd *rand.Rand) (Vector, Vector) {
+	x := make([]float64, len(a))
+	for i := range x {
+		x[i] = float64(rnd.Intn(19) - 9)
+	}
+	b := CompensatedResidual(a, make([]float64, len(a)), x)
+	for i := range b {
+		b[i] = -b[i]
+	}
+	return x, b
+}
@@ -0,0 +1,92 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Scalar тип элементов матрицы: вещественные или комплексные числа
+type Scalar interface {
+	float64 | complex128
+}
+
+// scalarAbs модуль вещественного или комплексного числа
+func scalarAbs[T Scalar](x T) float64 {
+	switch v := any(x).(type) {
+	case float64:
+		return math.Abs(v)
+	case complex128:
+		return cmplx.Abs(v)
+	}
+	return 0
+}
+
+// Eliminate прямой ход метода Гаусса с выбором главного элемента по столбцу
+// для расширенной матрицы n×(n+k). Возвращает треугольную матрицу (исходная
+// не изменяется) и определитель матрицы коэффициентов
+func Eliminate[T Scalar](matrix [][]T) ([][]T, T, error) {
+	m, _, det, err := eliminate(matrix, PivotPartial, nil)
+	return m, det, err
+}
+
+// eliminate прямой ход с заданной стратегией выбора главного элемента.
+// Возвращает треугольную матрицу, порядок неизвестных и определитель,
+// record (если не nil) получает исходную матрицу и все преобразования
+func eliminate[T Scalar](matrix [][]T, pivot PivotStrategy, record func(TraceOp, int, int, T, [][]T)) ([][]T, []int, T, error) {
+	n := len(matrix)
+	m := make([][]T, n)
+	for i := range matrix {
+		if len(matrix[i]) < n {
+			return nil, nil, 0, fmt.Errorf("%w: matrix isn't square", ErrDimension)
+		}
+		m[i] = append([]T{}, matrix[i]...)
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if record != nil {
+		record(TraceInitial, 0, 0, 0, m)
+	}
+
+	var det T = 1
+	for i := 0; i < n; i++ {
+		// каждая перестановка строк или столбцов меняет знак определителя
+		det *= eliminateStep(i, m, pivot, order, record)
+		if m[i][i] == 0 {
+			return m, order, 0, fmt.Errorf("%w: zero pivot in column %d", ErrSingular, i+1)
+		}
+		det *= m[i][i]
+	}
+	return m, order, det, nil
+}
+
+// BackSubstitute обратный ход для треугольной расширенной матрицы,
+// col - номер столбца правой части
+func BackSubstitute[T Scalar](m [][]T, col int) []T {
+	n := len(m)
+	x := make([]T, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := m[i][n+col]
+		for j := i + 1; j < n; j++ {
+			sum -= m[i][j] * x[j]
+		}
+		x[i] = sum / m[i][i]
+	}
+	return x
+}
+
+// Residual невязки b - Ax для расширенной матрицы m×(n+1) и решения x длины n
+func Residual[T Scalar](a [][]T, x []T) []T {
+	n := len(x)
+	deltas := make([]T, len(a))
+	for i := range a {
+		var left T
+		for j := 0; j < n; j++ {
+			left += a[i][j] * x[j]
+		}
+		deltas[i] = a[i][n] - left
+	}
+	return deltas
+}
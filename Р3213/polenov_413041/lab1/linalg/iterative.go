@@ -0,0 +1,269 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// IterativeResult результат итерационного метода решения СЛАУ
+type IterativeResult struct {
+	X          []float64
+	Iterations int
+	// Errors вектор погрешностей |x(k) - x(k-1)| на каждой итерации
+	Errors [][]float64
+	// ErrorNorms норма погрешности на каждой итерации (для больших систем,
+	// где векторы погрешностей не сохраняются)
+	ErrorNorms []float64
+	// ResidualHistory относительная невязка ||b - Ax|| / ||b|| на каждой итерации
+	// (методы Крылова)
+	ResidualHistory []float64
+}
+
+// isDiagonallyDominant проверяет достаточное условие сходимости:
+// |a_ii| >= сумма модулей остальных элементов строки для всех строк
+// и строгое неравенство хотя бы для одной
+func isDiagonallyDominant(a [][]float64) bool {
+	strict := false
+	for i := range a {
+		sum := 0.0
+		for j := range a[i] {
+			if j != i {
+				sum += math.Abs(a[i][j])
+			}
+		}
+		if math.Abs(a[i][i]) < sum {
+			return false
+		}
+		if math.Abs(a[i][i]) > sum {
+			strict = true
+		}
+	}
+	return strict
+}
+
+// dominantRowOrder пытается подобрать перестановку строк, при которой
+// матрица становится диагонально доминирующей. order[i] - номер исходной
+// строки, которая должна стоять на i-м месте
+func dominantRowOrder(a [][]float64) ([]int, bool) {
+	n := len(a)
+
+	// для каждой строки - столбцы, в которых элемент не меньше суммы остальных
+	candidates := make([][]int, n)
+	for i := range a {
+		total := 0.0
+		for j := range a[i] {
+			total += math.Abs(a[i][j])
+		}
+		for j := range a[i] {
+			if 2*math.Abs(a[i][j]) >= total && a[i][j] != 0 {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	order := make([]int, n)
+	used := make([]bool, n)
+	var assign func(row int) bool
+	assign = func(row int) bool {
+		if row == n {
+			return true
+		}
+		for _, col := range candidates[row] {
+			if used[col] {
+				continue
+			}
+			used[col] = true
+			order[col] = row
+			if assign(row + 1) {
+				return true
+			}
+			used[col] = false
+		}
+		return false
+	}
+
+	if !assign(0) || !isDiagonallyDominant(permuteRows(a, order)) {
+		return nil, false
+	}
+	return order, true
+}
+
+// permuteRows возвращает копию матрицы со строками в порядке order
+func permuteRows(a [][]float64, order []int) [][]float64 {
+	m := make([][]float64, len(a))
+	for i, row := range order {
+		m[i] = append([]float64{}, a[row]...)
+	}
+	return m
+}
+
+// PrepareIterative проверяет диагональное преобладание и при необходимости
+// переставляет строки системы. Возвращает матрицу, правую часть,
+// признак выполнения достаточного условия и признак перестановки строк
+func PrepareIterative(a Matrix, b Vector) (Matrix, Vector, bool, bool) {
+	if isDiagonallyDominant(a) {
+		return a, b, true, false
+	}
+	order, ok := dominantRowOrder(a)
+	if !ok {
+		return a, b, false, false
+	}
+	pb := make([]float64, len(b))
+	for i, row := range order {
+		pb[i] = b[row]
+	}
+	return permuteRows(a, order), pb, true, true
+}
+
+func checkZeroDiagonal(a [][]float64) error {
+	for i := range a {
+		if a[i][i] == 0 {
+			return fmt.Errorf("%w: zero element on the main diagonal in row %d", ErrSingular, i+1)
+		}
+	}
+	return nil
+}
+
+// Jacobi метод простых итераций (Якоби)
+func Jacobi(a Matrix, b Vector, eps float64, maxIter int) (IterativeResult, error) {
+	if err := checkZeroDiagonal(a); err != nil {
+		return IterativeResult{}, err
+	}
+	n := len(a)
+	x := make([]float64, n)
+	res := IterativeResult{}
+
+	for res.Iterations < maxIter {
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			sum := b[i]
+			for j := 0; j < n; j++ {
+				if j != i {
+					sum -= a[i][j] * x[j]
+				}
+			}
+			next[i] = sum / a[i][i]
+		}
+		res.Iterations++
+
+		errVec, done := iterationError(x, next, eps)
+		res.Errors = append(res.Errors, errVec)
+		x = next
+		if done {
+			res.X = x
+			return res, nil
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// GaussSeidel метод Гаусса-Зейделя (SOR с параметром релаксации 1)
+func GaussSeidel(a Matrix, b Vector, eps float64, maxIter int) (IterativeResult, error) {
+	return SOR(a, b, 1, eps, maxIter)
+}
+
+// SOR метод последовательной верхней релаксации с параметром omega из (0, 2)
+func SOR(a Matrix, b Vector, omega float64, eps float64, maxIter int) (IterativeResult, error) {
+	if omega <= 0 || omega >= 2 {
+		return IterativeResult{}, fmt.Errorf("%w: relaxation parameter must be in (0, 2), got %v", ErrInvalidArgument, omega)
+	}
+	if err := checkZeroDiagonal(a); err != nil {
+		return IterativeResult{}, err
+	}
+	n := len(a)
+	x := make([]float64, n)
+	res := IterativeResult{}
+
+	for res.Iterations < maxIter {
+		prev := append([]float64{}, x...)
+		for i := 0; i < n; i++ {
+			sum := b[i]
+			for j := 0; j < n; j++ {
+				if j != i {
+					sum -= a[i][j] * x[j]
+				}
+			}
+			x[i] = (1-omega)*x[i] + omega*sum/a[i][i]
+		}
+		res.Iterations++
+
+		errVec, done := iterationError(prev, x, eps)
+		res.Errors = append(res.Errors, errVec)
+		if done {
+			res.X = x
+			return res, nil
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// iterationError вектор погрешностей и признак достижения точности eps
+func iterationError(prev []float64, next []float64, eps float64) ([]float64, bool) {
+	errVec := make([]float64, len(next))
+	maximum := 0.0
+	for i := range next {
+		errVec[i] = math.Abs(next[i] - prev[i])
+		maximum = math.Max(maximum, errVec[i])
+	}
+	return errVec, maximum < eps
+}
+
+// EstimateOmega оценивает оптимальный параметр релаксации
+// omega = 2 / (1 + sqrt(1 - rho^2)), где rho - спектральный радиус
+// матрицы метода Якоби, найденный степенным методом
+func EstimateOmega(a Matrix) float64 {
+	n := len(a)
+	if checkZeroDiagonal(a) != nil {
+		return 1
+	}
+
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1
+	}
+
+	// среднее геометрическое отношений норм на последних итерациях
+	// сглаживает колебания при комплексных собственных значениях
+	const iterations, tail = 200, 20
+	logSum := 0.0
+	for k := 0; k < iterations; k++ {
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			sum := 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					sum -= a[i][j] * v[j]
+				}
+			}
+			next[i] = sum / a[i][i]
+		}
+		norm := VectorNorm(next)
+		if norm == 0 {
+			return 1
+		}
+		if k >= iterations-tail {
+			logSum += math.Log(norm / VectorNorm(v))
+		}
+		for i := range next {
+			next[i] /= norm
+		}
+		v = next
+	}
+
+	rho := math.Exp(logSum / tail)
+	if rho >= 1 {
+		return 1
+	}
+	return 2 / (1 + math.Sqrt(1-rho*rho))
+}
+
+// VectorNorm евклидова норма вектора
+func VectorNorm(v Vector) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
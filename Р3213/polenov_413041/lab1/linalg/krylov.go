@@ -0,0 +1,389 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Preconditioner предобусловливатель M, Apply вычисляет M⁻¹r
+type Preconditioner interface {
+	Apply(r Vector) Vector
+}
+
+// identityPreconditioner отсутствие предобусловливания
+type identityPreconditioner struct{}
+
+func (identityPreconditioner) Apply(r Vector) Vector {
+	return append([]float64{}, r...)
+}
+
+// JacobiPreconditioner диагональный предобусловливатель M = diag(A)
+type JacobiPreconditioner struct {
+	invDiag []float64
+}
+
+// NewJacobiPreconditioner строит предобусловливатель Якоби
+func NewJacobiPreconditioner(a *CSRMatrix) (*JacobiPreconditioner, error) {
+	d := a.Diagonal()
+	for i := range d {
+		if d[i] == 0 {
+			return nil, fmt.Errorf("%w: zero element on the main diagonal in row %d", ErrSingular, i+1)
+		}
+		d[i] = 1 / d[i]
+	}
+	return &JacobiPreconditioner{invDiag: d}, nil
+}
+
+func (p *JacobiPreconditioner) Apply(r Vector) Vector {
+	z := make([]float64, len(r))
+	for i := range r {
+		z[i] = r[i] * p.invDiag[i]
+	}
+	return z
+}
+
+// ILU0 неполное LU-разложение без заполнения: L и U имеют тот же
+// портрет, что и A, и хранятся вместе (единичная диагональ L не хранится)
+type ILU0 struct {
+	lu   *CSRMatrix
+	diag []int // позиции диагональных элементов в lu.Values
+}
+
+// NewILU0 строит неполное LU-разложение квадратной CSR-матрицы
+func NewILU0(a *CSRMatrix) (*ILU0, error) {
+	n := a.Rows
+	lu := &CSRMatrix{
+		Rows:   a.Rows,
+		Cols:   a.Cols,
+		RowPtr: a.RowPtr,
+		ColInd: a.ColInd,
+		Values: append([]float64{}, a.Values...),
+	}
+
+	diag := make([]int, n)
+	for i := 0; i < n; i++ {
+		diag[i] = -1
+		for k := lu.RowPtr[i]; k < lu.RowPtr[i+1]; k++ {
+			if lu.ColInd[k] == i {
+				diag[i] = k
+			}
+		}
+		if diag[i] < 0 {
+			return nil, fmt.Errorf("%w: ILU(0) needs nonzero diagonal, row %d has none", ErrSingular, i+1)
+		}
+	}
+
+	// pos[j] - позиция элемента (i, j) в текущей строке или -1
+	pos := make([]int, n)
+	for j := range pos {
+		pos[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		for k := lu.RowPtr[i]; k < lu.RowPtr[i+1]; k++ {
+			pos[lu.ColInd[k]] = k
+		}
+		for kk := lu.RowPtr[i]; kk < lu.RowPtr[i+1]; kk++ {
+			k := lu.ColInd[kk]
+			if k >= i {
+				break
+			}
+			pivot := lu.Values[diag[k]]
+			if pivot == 0 {
+				return nil, fmt.Errorf("%w: zero pivot in ILU(0) at row %d", ErrSingular, k+1)
+			}
+			lu.Values[kk] /= pivot
+			for jj := diag[k] + 1; jj < lu.RowPtr[k+1]; jj++ {
+				if p := pos[lu.ColInd[jj]]; p >= 0 {
+					lu.Values[p] -= lu.Values[kk] * lu.Values[jj]
+				}
+			}
+		}
+		for k := lu.RowPtr[i]; k < lu.RowPtr[i+1]; k++ {
+			pos[lu.ColInd[k]] = -1
+		}
+		if lu.Values[diag[i]] == 0 {
+			return nil, fmt.Errorf("%w: zero pivot in ILU(0) at row %d", ErrSingular, i+1)
+		}
+	}
+	return &ILU0{lu: lu, diag: diag}, nil
+}
+
+// Apply решает LUz = r прямой и обратной подстановкой
+func (p *ILU0) Apply(r Vector) Vector {
+	lu := p.lu
+	n := lu.Rows
+	z := append([]float64{}, r...)
+	for i := 0; i < n; i++ {
+		for k := lu.RowPtr[i]; k < p.diag[i]; k++ {
+			z[i] -= lu.Values[k] * z[lu.ColInd[k]]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := p.diag[i] + 1; k < lu.RowPtr[i+1]; k++ {
+			z[i] -= lu.Values[k] * z[lu.ColInd[k]]
+		}
+		z[i] /= lu.Values[p.diag[i]]
+	}
+	return z
+}
+
+// NewPreconditioner строит предобусловливатель по названию: "n" - без него,
+// "j" - Якоби, "ilu" - ILU(0)
+func NewPreconditioner(a *CSRMatrix, kind string) (Preconditioner, error) {
+	switch kind {
+	case "n":
+		return identityPreconditioner{}, nil
+	case "j":
+		return NewJacobiPreconditioner(a)
+	case "ilu":
+		return NewILU0(a)
+	default:
+		return nil, fmt.Errorf("%w: unknown preconditioner: %v", ErrInvalidArgument, kind)
+	}
+}
+
+// ConjugateGradient метод сопряженных градиентов для симметричной
+// положительно определенной матрицы с предобусловливателем m (nil - без него).
+// Остановка по относительной невязке ||r|| / ||b|| < eps
+func ConjugateGradient(a *CSRMatrix, b Vector, m Preconditioner, eps float64, maxIter int) (IterativeResult, error) {
+	if m == nil {
+		m = identityPreconditioner{}
+	}
+	n := a.Rows
+	x := make([]float64, n)
+	bNorm := VectorNorm(b)
+	if bNorm == 0 {
+		return IterativeResult{X: x}, nil
+	}
+
+	r := append([]float64{}, b...)
+	z := m.Apply(r)
+	p := append([]float64{}, z...)
+	rz := dot(r, z)
+
+	res := IterativeResult{}
+	for res.Iterations < maxIter {
+		ap := a.MulVec(p)
+		pap := dot(p, ap)
+		if pap <= 0 {
+			res.X = x
+			return res, fmt.Errorf("%w: pᵀAp = %g", ErrNotPositiveDefinite, pap)
+		}
+		alpha := rz / pap
+		for i := 0; i < n; i++ {
+			x[i] += alpha * p[i]
+			r[i] -= alpha * ap[i]
+		}
+		res.Iterations++
+
+		relative := VectorNorm(r) / bNorm
+		res.ResidualHistory = append(res.ResidualHistory, relative)
+		if relative < eps {
+			res.X = x
+			return res, nil
+		}
+
+		z = m.Apply(r)
+		rzNext := dot(r, z)
+		beta := rzNext / rz
+		rz = rzNext
+		for i := 0; i < n; i++ {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// BiCGSTAB стабилизированный метод бисопряженных градиентов для
+// несимметричных матриц с правым предобусловливанием
+func BiCGSTAB(a *CSRMatrix, b Vector, m Preconditioner, eps float64, maxIter int) (IterativeResult, error) {
+	if m == nil {
+		m = identityPreconditioner{}
+	}
+	n := a.Rows
+	x := make([]float64, n)
+	bNorm := VectorNorm(b)
+	if bNorm == 0 {
+		return IterativeResult{X: x}, nil
+	}
+
+	r := append([]float64{}, b...)
+	rHat := append([]float64{}, r...)
+	p := make([]float64, n)
+	v := make([]float64, n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+
+	res := IterativeResult{}
+	for res.Iterations < maxIter {
+		rhoNext := dot(rHat, r)
+		if rhoNext == 0 {
+			res.X = x
+			return res, fmt.Errorf("%w: BiCGSTAB breakdown: rho = 0", ErrNoConvergence)
+		}
+		beta := (rhoNext / rho) * (alpha / omega)
+		rho = rhoNext
+		for i := 0; i < n; i++ {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+
+		pHat := m.Apply(p)
+		v = a.MulVec(pHat)
+		rv := dot(rHat, v)
+		if rv == 0 {
+			res.X = x
+			return res, fmt.Errorf("%w: BiCGSTAB breakdown: (r^, v) = 0", ErrNoConvergence)
+		}
+		alpha = rho / rv
+
+		s := make([]float64, n)
+		for i := 0; i < n; i++ {
+			s[i] = r[i] - alpha*v[i]
+		}
+		res.Iterations++
+		if relative := VectorNorm(s) / bNorm; relative < eps {
+			for i := 0; i < n; i++ {
+				x[i] += alpha * pHat[i]
+			}
+			res.ResidualHistory = append(res.ResidualHistory, relative)
+			res.X = x
+			return res, nil
+		}
+
+		sHat := m.Apply(s)
+		t := a.MulVec(sHat)
+		tt := dot(t, t)
+		if tt == 0 {
+			res.X = x
+			return res, fmt.Errorf("%w: BiCGSTAB breakdown: t = 0", ErrNoConvergence)
+		}
+		omega = dot(t, s) / tt
+		for i := 0; i < n; i++ {
+			x[i] += alpha*pHat[i] + omega*sHat[i]
+			r[i] = s[i] - omega*t[i]
+		}
+
+		relative := VectorNorm(r) / bNorm
+		res.ResidualHistory = append(res.ResidualHistory, relative)
+		if relative < eps {
+			res.X = x
+			return res, nil
+		}
+		if omega == 0 {
+			res.X = x
+			return res, fmt.Errorf("%w: BiCGSTAB breakdown: omega = 0", ErrNoConvergence)
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// GMRES обобщенный метод минимальных невязок с перезапуском через restart
+// итераций и правым предобусловливанием. Ортогонализация по Арнольди
+// (модифицированный Грам-Шмидт), малая задача решается вращениями Гивенса
+func GMRES(a *CSRMatrix, b Vector, m Preconditioner, restart int, eps float64, maxIter int) (IterativeResult, error) {
+	if m == nil {
+		m = identityPreconditioner{}
+	}
+	if restart < 1 {
+		return IterativeResult{}, fmt.Errorf("%w: restart must be positive, got %d", ErrInvalidArgument, restart)
+	}
+	n := a.Rows
+	x := make([]float64, n)
+	bNorm := VectorNorm(b)
+	if bNorm == 0 {
+		return IterativeResult{X: x}, nil
+	}
+
+	res := IterativeResult{}
+	for res.Iterations < maxIter {
+		r := a.Residual(x, b)
+		beta := VectorNorm(r)
+		if beta/bNorm < eps {
+			res.X = x
+			return res, nil
+		}
+
+		// базис Крылова V и матрица Хессенберга H
+		v := [][]float64{make([]float64, n)}
+		for i := range r {
+			v[0][i] = r[i] / beta
+		}
+		h := make([][]float64, restart+1)
+		for i := range h {
+			h[i] = make([]float64, restart)
+		}
+		cs := make([]float64, restart)
+		sn := make([]float64, restart)
+		g := make([]float64, restart+1)
+		g[0] = beta
+
+		k := 0
+		for ; k < restart && res.Iterations < maxIter; k++ {
+			w := a.MulVec(m.Apply(v[k]))
+			for j := 0; j <= k; j++ {
+				h[j][k] = dot(w, v[j])
+				for i := range w {
+					w[i] -= h[j][k] * v[j][i]
+				}
+			}
+			h[k+1][k] = VectorNorm(w)
+
+			// применяем накопленные вращения к новому столбцу
+			for j := 0; j < k; j++ {
+				tmp := cs[j]*h[j][k] + sn[j]*h[j+1][k]
+				h[j+1][k] = -sn[j]*h[j][k] + cs[j]*h[j+1][k]
+				h[j][k] = tmp
+			}
+			denom := math.Hypot(h[k][k], h[k+1][k])
+			cs[k], sn[k] = h[k][k]/denom, h[k+1][k]/denom
+			h[k][k] = cs[k]*h[k][k] + sn[k]*h[k+1][k]
+			h[k+1][k] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+
+			res.Iterations++
+			relative := math.Abs(g[k+1]) / bNorm
+			res.ResidualHistory = append(res.ResidualHistory, relative)
+
+			next := make([]float64, n)
+			if norm := VectorNorm(w); norm != 0 {
+				for i := range w {
+					next[i] = w[i] / norm
+				}
+			}
+			v = append(v, next)
+			if relative < eps {
+				k++
+				break
+			}
+		}
+
+		// y = H⁻¹g, x += M⁻¹(Vy)
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= h[i][j] * y[j]
+			}
+			y[i] = sum / h[i][i]
+		}
+		update := make([]float64, n)
+		for j := 0; j < k; j++ {
+			for i := range update {
+				update[i] += y[j] * v[j][i]
+			}
+		}
+		update = m.Apply(update)
+		for i := range x {
+			x[i] += update[i]
+		}
+
+		if res.ResidualHistory[len(res.ResidualHistory)-1] < eps {
+			res.X = x
+			return res, nil
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
@@ -0,0 +1,106 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Factorization разложение матрицы, которое после построения решает
+// систему для любой правой части и дает определитель
+type Factorization interface {
+	Solve(b Vector) Vector
+	Determinant() float64
+}
+
+// LU разложение PA = LU с частичным выбором главного элемента.
+// Вычисляется один раз по матрице коэффициентов и затем решает
+// систему для любого количества правых частей
+type LU struct {
+	L [][]float64
+	U [][]float64
+	// P[i] - номер строки исходной матрицы, стоящей на i-м месте
+	P []int
+	// знак перестановки P (для определителя)
+	sign float64
+}
+
+// NewLU строит разложение для квадратной матрицы коэффициентов a (n×n).
+// Лишние столбцы (правые части) игнорируются
+func NewLU(a Matrix) (*LU, error) {
+	n := len(a)
+	lu := &LU{
+		L:    make([][]float64, n),
+		U:    make([][]float64, n),
+		P:    make([]int, n),
+		sign: 1,
+	}
+	for i := 0; i < n; i++ {
+		if len(a[i]) < n {
+			return nil, fmt.Errorf("%w: matrix isn't square", ErrDimension)
+		}
+		lu.L[i] = make([]float64, n)
+		lu.U[i] = append([]float64{}, a[i][:n]...)
+		lu.P[i] = i
+	}
+
+	for i := 0; i < n; i++ {
+		// выбор главного элемента по столбцу
+		row := i
+		for k := i + 1; k < n; k++ {
+			if math.Abs(lu.U[k][i]) > math.Abs(lu.U[row][i]) {
+				row = k
+			}
+		}
+		if lu.U[row][i] == 0 {
+			return nil, fmt.Errorf("%w, LU factorization doesn't exist", ErrSingular)
+		}
+		if row != i {
+			lu.U[i], lu.U[row] = lu.U[row], lu.U[i]
+			lu.L[i], lu.L[row] = lu.L[row], lu.L[i]
+			lu.P[i], lu.P[row] = lu.P[row], lu.P[i]
+			lu.sign = -lu.sign
+		}
+
+		lu.L[i][i] = 1
+		for k := i + 1; k < n; k++ {
+			factor := lu.U[k][i] / lu.U[i][i]
+			lu.L[k][i] = factor
+			for j := i; j < n; j++ {
+				lu.U[k][j] -= factor * lu.U[i][j]
+			}
+		}
+	}
+	return lu, nil
+}
+
+// Solve решает систему Ax = b прямой (Ly = Pb) и обратной (Ux = y) подстановкой
+func (lu *LU) Solve(b Vector) Vector {
+	n := len(lu.U)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[lu.P[i]]
+		for j := 0; j < i; j++ {
+			sum -= lu.L[i][j] * y[j]
+		}
+		y[i] = sum
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for j := i + 1; j < n; j++ {
+			sum -= lu.U[i][j] * x[j]
+		}
+		x[i] = sum / lu.U[i][i]
+	}
+	return x
+}
+
+// Determinant определитель как произведение диагонали U с учетом знака перестановки
+func (lu *LU) Determinant() float64 {
+	det := lu.sign
+	for i := range lu.U {
+		det *= lu.U[i][i]
+	}
+	return det
+}
@@ -0,0 +1,85 @@
+package linalg
+
+// machineEpsilon машинный эпсилон для float64
+const machineEpsilon = 2.220446049250313e-16
+
+// Matrix плотная матрица, хранимая по строкам. Расширенная матрица системы
+// содержит после столбцов коэффициентов столбцы правых частей
+type Matrix [][]float64
+
+// Vector вектор-столбец (решение, правая часть, невязки)
+type Vector []float64
+
+// NewMatrix нулевая матрица rows×cols
+func NewMatrix(rows int, cols int) Matrix {
+	m := make(Matrix, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+	return m
+}
+
+// Dims число строк и столбцов матрицы
+func (m Matrix) Dims() (int, int) {
+	if len(m) == 0 {
+		return 0, 0
+	}
+	return len(m), len(m[0])
+}
+
+// Clone копия матрицы, не разделяющая с ней память
+func (m Matrix) Clone() Matrix {
+	c := make(Matrix, len(m))
+	for i := range m {
+		c[i] = append([]float64{}, m[i]...)
+	}
+	return c
+}
+
+// MulVec произведение матрицы на вектор, лишние столбцы матрицы игнорируются
+func (m Matrix) MulVec(v Vector) Vector {
+	return matVec(m, v)
+}
+
+// Norm евклидова норма вектора
+func (v Vector) Norm() float64 {
+	return VectorNorm(v)
+}
+
+// SplitAugmented разделяет расширенную матрицу n×(n+k) на матрицу
+// коэффициентов n×n и k столбцов правых частей
+func SplitAugmented(matrix Matrix) (Matrix, []Vector) {
+	return SplitColumns(matrix, len(matrix))
+}
+
+// SplitColumns разделяет расширенную матрицу m×(n+k) на матрицу
+// коэффициентов m×n и k столбцов правых частей
+func SplitColumns(matrix Matrix, n int) (Matrix, []Vector) {
+	rows := len(matrix)
+	if rows == 0 {
+		return nil, nil
+	}
+	k := len(matrix[0]) - n
+
+	a := make(Matrix, rows)
+	b := make([]Vector, k)
+	for c := range b {
+		b[c] = make(Vector, rows)
+	}
+	for i := 0; i < rows; i++ {
+		a[i] = append([]float64{}, matrix[i][:n]...)
+		for c := 0; c < k; c++ {
+			b[c][i] = matrix[i][n+c]
+		}
+	}
+	return a, b
+}
+
+// Augment собирает расширенную матрицу из коэффициентов и одного столбца правой части
+func Augment[T Scalar](a [][]T, b []T) [][]T {
+	m := make([][]T, len(a))
+	for i := range a {
+		m[i] = append(append(make([]T, 0, len(a[i])+1), a[i]...), b[i])
+	}
+	return m
+}
@@ -0,0 +1,171 @@
+package linalg
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadMatrixMarket читает матрицу в формате Matrix Market.
+// Поддерживаются форматы coordinate (real, integer, pattern; general, symmetric)
+// и array (плотная матрица по столбцам, например вектор правой части; для
+// symmetric записан только нижний треугольник)
+func ReadMatrixMarket(path string) (*CSRMatrix, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIO, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	line := 0
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("%w: file is empty", ErrParse)
+	}
+	line++
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" {
+		return nil, parseError(lineNumber(line), "expected \"%%%%MatrixMarket matrix <format> <field> <symmetry>\" header")
+	}
+	format, field, symmetry := header[2], header[3], header[4]
+	if format != "coordinate" && format != "array" {
+		return nil, parseError(lineNumber(line), "unsupported format %q", format)
+	}
+	if field != "real" && field != "integer" && field != "pattern" {
+		return nil, parseError(lineNumber(line), "unsupported field %q", field)
+	}
+	if symmetry != "general" && symmetry != "symmetric" {
+		return nil, parseError(lineNumber(line), "unsupported symmetry %q", symmetry)
+	}
+
+	// строка размеров после комментариев
+	var sizes []string
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		sizes = strings.Fields(text)
+		break
+	}
+	if format == "coordinate" && len(sizes) != 3 || format == "array" && len(sizes) != 2 {
+		return nil, parseError(lineNumber(line), "invalid size line")
+	}
+	dims := make([]int, len(sizes))
+	for i := range sizes {
+		dims[i], err = strconv.Atoi(sizes[i])
+		if err != nil || dims[i] < 0 {
+			return nil, parseError(lineNumber(line), "invalid size %q", sizes[i])
+		}
+	}
+	rows, cols := dims[0], dims[1]
+	if symmetry == "symmetric" && rows != cols {
+		return nil, parseError(lineNumber(line), "symmetric matrix must be square, got %dx%d", rows, cols)
+	}
+
+	expected := rows * cols
+	switch {
+	case format == "coordinate":
+		expected = dims[2]
+	case symmetry == "symmetric":
+		expected = rows * (rows + 1) / 2
+	}
+
+	var is, js []int
+	var vs []float64
+	count := 0
+	// позиция следующего элемента в формате array
+	ai, aj := 0, 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if count >= expected {
+			return nil, parseError(lineNumber(line), "unexpected entry after %d entries", expected)
+		}
+
+		var i, j int
+		var v float64
+		if format == "array" {
+			if len(fields) != 1 {
+				return nil, parseError(lineNumber(line), "expected one value")
+			}
+			i, j = ai, aj
+			ai++
+			if ai == rows {
+				// следующий столбец, для symmetric - с диагонального элемента
+				aj++
+				ai = 0
+				if symmetry == "symmetric" {
+					ai = aj
+				}
+			}
+			v, err = strconv.ParseFloat(fields[0], 64)
+		} else {
+			want := 3
+			if field == "pattern" {
+				want = 2
+			}
+			if len(fields) != want {
+				return nil, parseError(lineNumber(line), "expected %d fields, got %d", want, len(fields))
+			}
+			i, err = strconv.Atoi(fields[0])
+			if err == nil {
+				j, err = strconv.Atoi(fields[1])
+			}
+			if err != nil {
+				return nil, parseError(lineNumber(line), "invalid index: %v", err)
+			}
+			i, j = i-1, j-1
+			if i < 0 || i >= rows || j < 0 || j >= cols {
+				return nil, parseError(lineNumber(line), "index (%d, %d) out of range", i+1, j+1)
+			}
+			v = 1
+			if field != "pattern" {
+				v, err = strconv.ParseFloat(fields[2], 64)
+			}
+		}
+		if err != nil {
+			return nil, parseError(lineNumber(line), "error parsing value: %v", err)
+		}
+
+		is, js, vs = append(is, i), append(js, j), append(vs, v)
+		if symmetry == "symmetric" && i != j {
+			is, js, vs = append(is, j), append(js, i), append(vs, v)
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIO, err)
+	}
+
+	if count != expected {
+		return nil, fmt.Errorf("%w: expected %d entries, got %d", ErrParse, expected, count)
+	}
+	return NewCSRFromTriplets(rows, cols, is, js, vs), nil
+}
+
+// ReadMatrixMarketVector читает правую часть из файла Matrix Market
+// с одним столбцом (в формате array или coordinate)
+func ReadMatrixMarketVector(path string) (Vector, error) {
+	m, err := ReadMatrixMarket(path)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cols != 1 {
+		return nil, fmt.Errorf("%w: right side must have one column, got %d", ErrDimension, m.Cols)
+	}
+	b := make([]float64, m.Rows)
+	for i := range b {
+		b[i] = m.At(i, 0)
+	}
+	return b, nil
+}
@@ -0,0 +1,175 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// DefaultBlockSize ширина панели блочного LU-разложения: блок строк
+// U12 шириной 64 столбца помещается в кэш процессора
+const DefaultBlockSize = 64
+
+// BlockedLUThreshold порядок матрицы, начиная с которого выгоднее
+// блочное параллельное разложение
+const BlockedLUThreshold = 200
+
+// NewBlockedLU строит разложение PA = LU по блокам ширины blockSize.
+// Панель из blockSize столбцов раскладывается последовательно с выбором
+// главного элемента по столбцу, а обновление оставшейся подматрицы
+// A22 -= L21·U12 делится по строкам между workers горутинами.
+// При workers <= 0 используется число процессоров. Результат совпадает
+// с NewLU с точностью до ошибок округления
+func NewBlockedLU(a Matrix, blockSize int, workers int) (*LU, error) {
+	n := len(a)
+	if blockSize <= 0 {
+		blockSize = DefaultBlockSize
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// матрица хранится непрерывно по строкам, L и U на одном месте
+	m := make([]float64, n*n)
+	for i := range a {
+		if len(a[i]) < n {
+			return nil, fmt.Errorf("%w: matrix isn't square", ErrDimension)
+		}
+		copy(m[i*n:(i+1)*n], a[i][:n])
+	}
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign := 1.0
+
+	for k0 := 0; k0 < n; k0 += blockSize {
+		k1 := min(k0+blockSize, n)
+
+		// разложение панели столбцов k0..k1-1
+		for i := k0; i < k1; i++ {
+			row := i
+			for k := i + 1; k < n; k++ {
+				if math.Abs(m[k*n+i]) > math.Abs(m[row*n+i]) {
+					row = k
+				}
+			}
+			if m[row*n+i] == 0 {
+				return nil, fmt.Errorf("%w, LU factorization doesn't exist", ErrSingular)
+			}
+			if row != i {
+				ri, rr := m[i*n:(i+1)*n], m[row*n:(row+1)*n]
+				for j := range ri {
+					ri[j], rr[j] = rr[j], ri[j]
+				}
+				perm[i], perm[row] = perm[row], perm[i]
+				sign = -sign
+			}
+
+			pivot := m[i*n+i]
+			for k := i + 1; k < n; k++ {
+				m[k*n+i] /= pivot
+				factor := m[k*n+i]
+				if factor == 0 {
+					continue
+				}
+				for j := i + 1; j < k1; j++ {
+					m[k*n+j] -= factor * m[i*n+j]
+				}
+			}
+		}
+		if k1 == n {
+			break
+		}
+
+		// U12 = L11⁻¹·A12
+		for i := k0; i < k1; i++ {
+			ui := m[i*n+k1 : (i+1)*n]
+			for p := k0; p < i; p++ {
+				lip := m[i*n+p]
+				up := m[p*n+k1 : (p+1)*n]
+				for j := range ui {
+					ui[j] -= lip * up[j]
+				}
+			}
+		}
+
+		// A22 -= L21·U12, строки делятся между горутинами
+		parallelRows(k1, n, workers, func(from, to int) {
+			for i := from; i < to; i++ {
+				ai := m[i*n+k1 : (i+1)*n]
+				for p := k0; p < k1; p++ {
+					lip := m[i*n+p]
+					if lip == 0 {
+						continue
+					}
+					up := m[p*n+k1 : (p+1)*n]
+					for j := range ai {
+						ai[j] -= lip * up[j]
+					}
+				}
+			}
+		})
+	}
+
+	lu := &LU{
+		L:    make([][]float64, n),
+		U:    make([][]float64, n),
+		P:    perm,
+		sign: sign,
+	}
+	for i := 0; i < n; i++ {
+		lu.L[i] = make([]float64, n)
+		lu.U[i] = make([]float64, n)
+		copy(lu.L[i][:i], m[i*n:i*n+i])
+		lu.L[i][i] = 1
+		copy(lu.U[i][i:], m[i*n+i:(i+1)*n])
+	}
+	return lu, nil
+}
+
+// parallelRows делит строки from..to-1 на workers непрерывных частей
+// и обрабатывает их параллельно
+func parallelRows(from int, to int, workers int, body func(from, to int)) {
+	rows := to - from
+	if workers <= 1 || rows < 2*workers {
+		body(from, to)
+		return
+	}
+	chunk := (rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := from; start < to; start += chunk {
+		end := min(start+chunk, to)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			body(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// LUFlops число операций с плавающей точкой LU-разложения порядка n
+func LUFlops(n int) float64 {
+	return 2 * math.Pow(float64(n), 3) / 3
+}
+
+// MaxFactorDifference максимальное относительное расхождение множителей U
+// двух разложений одной матрицы и признак совпадения перестановок
+func MaxFactorDifference(x *LU, y *LU) (float64, bool) {
+	samePerm := true
+	for i := range x.P {
+		if x.P[i] != y.P[i] {
+			samePerm = false
+		}
+	}
+	diff, scale := 0.0, 0.0
+	for i := range x.U {
+		for j := range x.U[i] {
+			diff = math.Max(diff, math.Abs(x.U[i][j]-y.U[i][j]))
+			scale = math.Max(scale, math.Abs(x.U[i][j]))
+		}
+	}
+	return diff / scale, samePerm
+}
@@ -0,0 +1,415 @@
+package linalg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// matrixToken элемент матрицы из файла и его позиция для сообщений об ошибках
+type matrixToken struct {
+	Text string
+	// Pos позиция в файле: "line 3, column 7" или "A[2][3]" для JSON
+	Pos string
+	// pair два числа, если запятая в "1,5" окажется разделителем, а не десятичной
+	pair []matrixToken
+}
+
+// readMatrixTokens читает файл с матрицей и возвращает ее элементы
+// с позициями и число неизвестных. Формат выбирается по расширению:
+// .csv - таблица без строки размерности, .json - объект {"A": [[...]], "b": [...]},
+// остальные - текстовый формат с размерностью в первой строке.
+// withRHS - в каждой строке после коэффициентов должна быть хотя бы одна
+// правая часть, иначе файл содержит только квадратную матрицу коэффициентов
+func readMatrixTokens(path string, withRHS bool) ([][]matrixToken, int, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrIO, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSVTokens(file, withRHS)
+	case ".json":
+		return parseJSONTokens(file, withRHS)
+	default:
+		return parseTextTokens(file, withRHS)
+	}
+}
+
+// checkShape проверяет число уравнений m и неизвестных n
+func checkShape(m int, n int) error {
+	if n < 2 || n > MaxFileDimension {
+		return fmt.Errorf("%w: unsupported number of unknowns %v", ErrDimension, n)
+	}
+	if m < 1 || m > maxEquations {
+		return fmt.Errorf("%w: unsupported number of equations %v", ErrDimension, m)
+	}
+	return nil
+}
+
+// parseTextTokens разбирает текстовый формат. Первая значащая строка
+// содержит порядок n или число уравнений m и неизвестных n, затем идут
+// m строк расширенной матрицы. Числа разделяются любыми пробельными
+// символами, ";" или запятой с пробелом после нее. Одна запятая внутри
+// числа ("1,5") - десятичная или разделитель двух чисел: выбирается прочтение,
+// при котором строки матрицы имеют подходящую длину. Текст после "#" - комментарий
+func parseTextTokens(file []byte, withRHS bool) ([][]matrixToken, int, error) {
+	var rows [][]matrixToken
+	var lines []int
+	for i, line := range strings.Split(string(file), "\n") {
+		row, err := tokenizeLine(line, i+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+			lines = append(lines, i+1)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, 0, fmt.Errorf("%w: file is empty", ErrParse)
+	}
+
+	// "n" - квадратная система, "m n" - m уравнений с n неизвестными
+	header := rows[0]
+	if len(header) > 2 {
+		return nil, 0, parseError(lineNumber(lines[0]), "first line must contain matrix dimension")
+	}
+	n, err := strconv.Atoi(header[len(header)-1].Text)
+	if err != nil {
+		return nil, 0, parseError(header[len(header)-1].Pos, "error converting matrix dimension: %q", header[len(header)-1].Text)
+	}
+	m := n
+	if len(header) == 2 {
+		m, err = strconv.Atoi(header[0].Text)
+		if err != nil {
+			return nil, 0, parseError(header[0].Pos, "error converting number of equations: %q", header[0].Text)
+		}
+	}
+	if err := checkShape(m, n); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", lineNumber(lines[0]), err)
+	}
+
+	rows, lines = rows[1:], lines[1:]
+	if len(rows) < m {
+		return nil, 0, fmt.Errorf("%w: expected %d matrix rows, got %d", ErrParse, m, len(rows))
+	}
+	if len(rows) > m {
+		return nil, 0, parseError(lineNumber(lines[m]), "unexpected row after %d matrix rows", m)
+	}
+	if err := resolveCommas(rows, n, withRHS); err != nil {
+		return nil, 0, err
+	}
+	if err := checkRowLengths(rows, n, withRHS, func(i int) string { return fmt.Sprintf("line %d", lines[i]) }); err != nil {
+		return nil, 0, err
+	}
+	return rows, n, nil
+}
+
+// resolveCommas выбирает прочтение чисел вида "1,5": в каждой строке все
+// такие запятые либо десятичные, либо разделяют два числа. Длина строк
+// должна быть одинаковой и подходить по числу неизвестных n; если подходят
+// обе длины, запись неоднозначна. Если не подходит ни одна, запятые
+// считаются десятичными, а ошибку длины сообщит checkRowLengths
+func resolveCommas(rows [][]matrixToken, n int, withRHS bool) error {
+	valid := func(length int) bool {
+		if withRHS {
+			return length >= n+1
+		}
+		return length == n
+	}
+	// длины строк, возможные во всех строках
+	var common []int
+	var ambiguous *matrixToken
+	for i, row := range rows {
+		pairs := 0
+		for j := range row {
+			if row[j].pair != nil {
+				pairs++
+				if ambiguous == nil {
+					ambiguous = &row[j]
+				}
+			}
+		}
+		var lengths []int
+		for _, length := range []int{len(row), len(row) + pairs} {
+			if valid(length) && (i == 0 || slices.Contains(common, length)) && !slices.Contains(lengths, length) {
+				lengths = append(lengths, length)
+			}
+		}
+		common = lengths
+	}
+	if len(common) > 1 {
+		return parseError(ambiguous.Pos, "ambiguous number %q: use \".\" as decimal separator or \", \" between numbers", ambiguous.Text)
+	}
+
+	for i, row := range rows {
+		split := len(common) == 1 && len(row) != common[0]
+		var resolved []matrixToken
+		for _, tok := range row {
+			switch {
+			case tok.pair == nil:
+				resolved = append(resolved, tok)
+			case split:
+				resolved = append(resolved, tok.pair...)
+			default:
+				resolved = append(resolved, matrixToken{Text: strings.Replace(tok.Text, ",", ".", 1), Pos: tok.Pos})
+			}
+		}
+		rows[i] = resolved
+	}
+	return nil
+}
+
+// checkRowLengths проверяет, что во всех строках одинаковое число элементов:
+// не меньше n+1 для системы с правыми частями (их число определяется первой
+// строкой) и ровно n для матрицы коэффициентов
+func checkRowLengths(rows [][]matrixToken, n int, withRHS bool, pos func(i int) string) error {
+	for i, row := range rows {
+		if withRHS && len(row) < n+1 {
+			return parseError(pos(i), "expected %d coefficients and at least one right side, got %d numbers", n, len(row))
+		}
+		if !withRHS && len(row) != n {
+			return parseError(pos(i), "expected %d numbers, got %d", n, len(row))
+		}
+		if len(row) != len(rows[0]) {
+			return parseError(pos(i), "expected %d numbers as in the first row, got %d", len(rows[0]), len(row))
+		}
+	}
+	return nil
+}
+
+// tokenizeLine разбивает строку текстового файла на числа с номерами столбцов
+func tokenizeLine(line string, lineNo int) ([]matrixToken, error) {
+	runes := []rune(line)
+	var tokens []matrixToken
+
+	isSeparator := func(i int) bool {
+		r := runes[i]
+		if unicode.IsSpace(r) || r == ';' {
+			return true
+		}
+		// запятая - разделитель, если за ней пробел или конец строки
+		return r == ',' && (i+1 == len(runes) || unicode.IsSpace(runes[i+1]) || runes[i+1] == '#')
+	}
+
+	for i := 0; i < len(runes); {
+		if runes[i] == '#' {
+			break
+		}
+		if isSeparator(i) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && !isSeparator(i) && runes[i] != '#' {
+			i++
+		}
+		text := string(runes[start:i])
+
+		switch strings.Count(text, ",") {
+		case 0:
+			tokens = append(tokens, matrixToken{Text: text, Pos: linePos(lineNo, start+1)})
+		case 1:
+			// десятичная запятая или два числа, решает resolveCommas
+			left, right, _ := strings.Cut(text, ",")
+			tok := matrixToken{Text: text, Pos: linePos(lineNo, start+1)}
+			if left != "" && right != "" {
+				tok.pair = []matrixToken{
+					{Text: left, Pos: linePos(lineNo, start+1)},
+					{Text: right, Pos: linePos(lineNo, start+len([]rune(left))+2)},
+				}
+			}
+			tokens = append(tokens, tok)
+		default:
+			// числа, разделенные запятыми без пробелов
+			col := start + 1
+			for _, part := range strings.Split(text, ",") {
+				if part == "" {
+					return nil, parseError(linePos(lineNo, col), "empty number between commas")
+				}
+				tokens = append(tokens, matrixToken{Text: part, Pos: linePos(lineNo, col)})
+				col += len([]rune(part)) + 1
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// parseCSVTokens разбирает CSV: каждая запись - строка расширенной матрицы
+// с одним столбцом правой части, строка размерности не нужна. Разделитель
+// ",", а если в строках данных встречается ";" - точка с запятой и десятичная
+// запятая. Строки, начинающиеся с "#", пропускаются. Первая строка без чисел
+// считается строкой заголовков, если в ней столько же столбцов, сколько в данных
+func parseCSVTokens(file []byte, withRHS bool) ([][]matrixToken, int, error) {
+	r := csv.NewReader(bytes.NewReader(file))
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	// длины строк проверяет checkRowLengths с номером строки в сообщении
+	r.FieldsPerRecord = -1
+	decimalComma := false
+	for _, line := range strings.Split(string(file), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") && strings.ContainsRune(line, ';') {
+			decimalComma = true
+			break
+		}
+	}
+	if decimalComma {
+		r.Comma = ';'
+	}
+
+	var rows [][]matrixToken
+	var lines []int
+	// строка без чисел в начале файла, возможно заголовки
+	var header []matrixToken
+	headerLine := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, 0, parseError(linePos(parseErr.Line, parseErr.Column), "%v", parseErr.Err)
+			}
+			return nil, 0, fmt.Errorf("%w: error reading csv: %v", ErrParse, err)
+		}
+
+		row := make([]matrixToken, len(record))
+		numeric := false
+		for j, field := range record {
+			line, col := r.FieldPos(j)
+			text := strings.TrimSpace(field)
+			if decimalComma {
+				text = strings.Replace(text, ",", ".", 1)
+			}
+			if _, err := strconv.ParseComplex(text, 128); err == nil {
+				numeric = true
+			}
+			row[j] = matrixToken{Text: text, Pos: linePos(line, col)}
+		}
+		line, _ := r.FieldPos(0)
+		if len(rows) == 0 && header == nil && !numeric {
+			header, headerLine = row, line
+			continue
+		}
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+	if header != nil && len(rows) > 0 && len(header) != len(rows[0]) {
+		// не заголовки, а ошибочная строка данных
+		return nil, 0, parseError(lineNumber(headerLine), "row without numbers has %d columns, data rows have %d", len(header), len(rows[0]))
+	}
+	if len(rows) == 0 {
+		return nil, 0, fmt.Errorf("%w: file is empty", ErrParse)
+	}
+
+	n := len(rows[0])
+	if withRHS {
+		n--
+	}
+	if err := checkShape(len(rows), n); err != nil {
+		return nil, 0, err
+	}
+	if err := checkRowLengths(rows, n, withRHS, func(i int) string { return fmt.Sprintf("line %d", lines[i]) }); err != nil {
+		return nil, 0, err
+	}
+	return rows, n, nil
+}
+
+// jsonSystem система в формате JSON: матрица коэффициентов и правая часть.
+// Элементы - числа или строки (для дробей "1/3" и комплексных чисел "3+4i"),
+// правая часть не нужна только для поиска собственных значений
+type jsonSystem struct {
+	A [][]json.RawMessage `json:"A"`
+	B []json.RawMessage   `json:"b"`
+}
+
+// parseJSONTokens разбирает объект {"A": [[...]], "b": [...]}
+func parseJSONTokens(file []byte, withRHS bool) ([][]matrixToken, int, error) {
+	var sys jsonSystem
+	if err := json.Unmarshal(file, &sys); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := offsetPos(file, syntaxErr.Offset)
+			return nil, 0, parseError(linePos(line, col), "%v", err)
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			line, col := offsetPos(file, typeErr.Offset)
+			return nil, 0, parseError(linePos(line, col), "field %q must be an array", typeErr.Field)
+		}
+		return nil, 0, fmt.Errorf("%w: error parsing json: %v", ErrParse, err)
+	}
+	if len(sys.A) == 0 {
+		return nil, 0, fmt.Errorf("%w: field \"A\" is missing or empty", ErrParse)
+	}
+
+	m, n := len(sys.A), len(sys.A[0])
+	if err := checkShape(m, n); err != nil {
+		return nil, 0, err
+	}
+	if !withRHS {
+		sys.B = nil
+	} else if sys.B == nil {
+		return nil, 0, fmt.Errorf("%w: field \"b\" is missing", ErrParse)
+	}
+	if sys.B != nil && len(sys.B) != m {
+		return nil, 0, parseError("b", "expected %d numbers, got %d", m, len(sys.B))
+	}
+
+	rows := make([][]matrixToken, m)
+	for i := range sys.A {
+		if len(sys.A[i]) != n {
+			return nil, 0, parseError(fmt.Sprintf("A[%d]", i), "expected %d numbers, got %d", n, len(sys.A[i]))
+		}
+		for j, raw := range sys.A[i] {
+			tok, err := jsonToken(raw, fmt.Sprintf("A[%d][%d]", i, j))
+			if err != nil {
+				return nil, 0, err
+			}
+			rows[i] = append(rows[i], tok)
+		}
+		if sys.B != nil {
+			tok, err := jsonToken(sys.B[i], fmt.Sprintf("b[%d]", i))
+			if err != nil {
+				return nil, 0, err
+			}
+			rows[i] = append(rows[i], tok)
+		}
+	}
+	return rows, n, nil
+}
+
+// jsonToken текст элемента JSON: число как есть или содержимое строки
+func jsonToken(raw json.RawMessage, pos string) (matrixToken, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return matrixToken{Text: strings.TrimSpace(s), Pos: pos}, nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(raw, &num); err != nil {
+		return matrixToken{}, parseError(pos, "expected number, got %s", raw)
+	}
+	return matrixToken{Text: num.String(), Pos: pos}, nil
+}
+
+// offsetPos переводит смещение в байтах в номер строки и столбца
+func offsetPos(file []byte, offset int64) (int, int) {
+	offset = min(offset, int64(len(file)))
+	before := file[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len([]rune(string(before[bytes.LastIndexByte(before, '\n')+1:])))
+	return line, max(col, 1)
+}
@@ -0,0 +1,197 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// QR разложение A = QR методом отражений Хаусхолдера для матрицы m×n, m >= n.
+// Q хранится в виде векторов отражений, R - верхняя треугольная n×n
+type QR struct {
+	m, n int
+	// v[k] - вектор отражения H_k = I - 2vvᵀ (нормированный, длины m-k)
+	v [][]float64
+	R [][]float64
+}
+
+// NewQR строит разложение для матрицы a с числом строк не меньше числа столбцов
+func NewQR(a Matrix) (*QR, error) {
+	m := len(a)
+	if m == 0 {
+		return nil, fmt.Errorf("%w: matrix is empty", ErrDimension)
+	}
+	n := len(a[0])
+	if m < n {
+		return nil, fmt.Errorf("%w: QR factorization needs rows >= columns, got %dx%d", ErrDimension, m, n)
+	}
+
+	r := make([][]float64, m)
+	for i := range a {
+		r[i] = append([]float64{}, a[i][:n]...)
+	}
+
+	qr := &QR{m: m, n: n, v: make([][]float64, n)}
+	for k := 0; k < n; k++ {
+		norm := 0.0
+		for i := k; i < m; i++ {
+			norm += r[i][k] * r[i][k]
+		}
+		norm = math.Sqrt(norm)
+
+		v := make([]float64, m-k)
+		if norm == 0 {
+			qr.v[k] = v
+			continue
+		}
+		// знак выбирается так, чтобы избежать вычитания близких чисел
+		alpha := -norm
+		if r[k][k] < 0 {
+			alpha = norm
+		}
+		for i := k; i < m; i++ {
+			v[i-k] = r[i][k]
+		}
+		v[0] -= alpha
+		vNorm := VectorNorm(v)
+		for i := range v {
+			v[i] /= vNorm
+		}
+		qr.v[k] = v
+
+		for j := k; j < n; j++ {
+			dot := 0.0
+			for i := k; i < m; i++ {
+				dot += v[i-k] * r[i][j]
+			}
+			for i := k; i < m; i++ {
+				r[i][j] -= 2 * dot * v[i-k]
+			}
+		}
+	}
+
+	qr.R = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		qr.R[i] = make([]float64, n)
+		copy(qr.R[i][i:], r[i][i:n])
+	}
+	return qr, nil
+}
+
+// ApplyQT вычисляет Qᵀb
+func (qr *QR) ApplyQT(b Vector) Vector {
+	y := append([]float64{}, b...)
+	for k := 0; k < qr.n; k++ {
+		qr.reflect(k, y)
+	}
+	return y
+}
+
+// ApplyQ вычисляет Qz для вектора длины m
+func (qr *QR) ApplyQ(z Vector) Vector {
+	y := append([]float64{}, z...)
+	for k := qr.n - 1; k >= 0; k-- {
+		qr.reflect(k, y)
+	}
+	return y
+}
+
+// reflect применяет отражение H_k к вектору y на месте
+func (qr *QR) reflect(k int, y []float64) {
+	v := qr.v[k]
+	dot := 0.0
+	for i := range v {
+		dot += v[i] * y[k+i]
+	}
+	for i := range v {
+		y[k+i] -= 2 * dot * v[i]
+	}
+}
+
+// Q явная ортогональная матрица m×m
+func (qr *QR) Q() Matrix {
+	q := make([][]float64, qr.m)
+	for i := range q {
+		q[i] = make([]float64, qr.m)
+	}
+	e := make([]float64, qr.m)
+	for j := 0; j < qr.m; j++ {
+		e[j] = 1
+		col := qr.ApplyQ(e)
+		e[j] = 0
+		for i := range col {
+			q[i][j] = col[i]
+		}
+	}
+	return q
+}
+
+// checkFullRank проверяет, что диагональ R не содержит нулей
+func (qr *QR) checkFullRank() error {
+	maximum := 0.0
+	for i := range qr.R {
+		maximum = math.Max(maximum, math.Abs(qr.R[i][i]))
+	}
+	tol := float64(max(qr.m, qr.n)) * machineEpsilon * maximum
+	for i := range qr.R {
+		if math.Abs(qr.R[i][i]) <= tol {
+			return fmt.Errorf("%w: matrix doesn't have full column rank", ErrSingular)
+		}
+	}
+	return nil
+}
+
+// LeastSquares решает систему Ax = b с матрицей m×n.
+// При m >= n находится решение по методу наименьших квадратов (min ||Ax - b||),
+// при m < n - решение с минимальной нормой через QR-разложение Aᵀ
+func LeastSquares(a Matrix, b Vector) (Vector, error) {
+	m := len(a)
+	if m == 0 {
+		return nil, fmt.Errorf("%w: matrix is empty", ErrDimension)
+	}
+	n := len(a[0])
+
+	if m >= n {
+		qr, err := NewQR(a)
+		if err != nil {
+			return nil, err
+		}
+		if err := qr.checkFullRank(); err != nil {
+			return nil, err
+		}
+		y := qr.ApplyQT(b)
+		x := make([]float64, n)
+		for i := n - 1; i >= 0; i-- {
+			sum := y[i]
+			for j := i + 1; j < n; j++ {
+				sum -= qr.R[i][j] * x[j]
+			}
+			x[i] = sum / qr.R[i][i]
+		}
+		return x, nil
+	}
+
+	// Aᵀ = QR, тогда A = RᵀQᵀ: решаем Rᵀz = b и x = Q(z, 0)
+	at := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		at[j] = make([]float64, m)
+		for i := 0; i < m; i++ {
+			at[j][i] = a[i][j]
+		}
+	}
+	qr, err := NewQR(at)
+	if err != nil {
+		return nil, err
+	}
+	if err := qr.checkFullRank(); err != nil {
+		return nil, fmt.Errorf("%w: matrix doesn't have full row rank", ErrSingular)
+	}
+	z := make([]float64, n)
+	for i := 0; i < m; i++ {
+		sum := b[i]
+		for j := 0; j < i; j++ {
+			sum -= qr.R[j][i] * z[j]
+		}
+		z[i] = sum / qr.R[i][i]
+	}
+	return qr.ApplyQ(z), nil
+}
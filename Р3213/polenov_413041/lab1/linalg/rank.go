@@ -0,0 +1,162 @@
+package linalg
+
+import "math"
+
+// SystemKind тип системы по теореме Кронекера-Капелли
+type SystemKind int
+
+const (
+	// SystemUnique единственное решение
+	SystemUnique SystemKind = iota
+	// SystemInconsistent система несовместна
+	SystemInconsistent
+	// SystemInfinite бесконечно много решений
+	SystemInfinite
+)
+
+func (k SystemKind) String() string {
+	switch k {
+	case SystemInconsistent:
+		return "система несовместна (решений нет)"
+	case SystemInfinite:
+		return "бесконечно много решений"
+	default:
+		return "единственное решение"
+	}
+}
+
+// SystemSolution результат исследования системы на совместность
+type SystemSolution struct {
+	Kind SystemKind
+	// ранг матрицы коэффициентов и расширенной матрицы
+	Rank          int
+	AugmentedRank int
+	// частное решение (свободные неизвестные равны нулю)
+	Particular []float64
+	// базис пространства решений однородной системы
+	NullSpace [][]float64
+}
+
+// rankTolerance порог, ниже которого элемент считается нулевым,
+// масштабируется по размеру и максимальному элементу матрицы
+func rankTolerance(m [][]float64) float64 {
+	maximum := 0.0
+	cols := 0
+	for _, row := range m {
+		cols = max(cols, len(row))
+		for _, v := range row {
+			maximum = math.Max(maximum, math.Abs(v))
+		}
+	}
+	return float64(max(len(m), cols)) * machineEpsilon * math.Max(maximum, 1)
+}
+
+// reducedRowEchelon приводит матрицу к приведенному ступенчатому виду
+// с выбором главного элемента по столбцу. Ведущие элементы ищутся только
+// в первых cols столбцах, возвращаются номера ведущих столбцов.
+// Преобразования записываются в trace, если он не nil
+func reducedRowEchelon(m [][]float64, cols int, tol float64, trace *EliminationTrace) []int {
+	trace.record(TraceInitial, 0, 0, 0, m)
+	var pivots []int
+	row := 0
+	for col := 0; col < cols && row < len(m); col++ {
+		best := row
+		for k := row + 1; k < len(m); k++ {
+			if math.Abs(m[k][col]) > math.Abs(m[best][col]) {
+				best = k
+			}
+		}
+		if math.Abs(m[best][col]) <= tol {
+			for k := row; k < len(m); k++ {
+				m[k][col] = 0
+			}
+			continue
+		}
+		if best != row {
+			m[row], m[best] = m[best], m[row]
+			trace.record(TraceSwapRows, row, best, 0, m)
+		}
+
+		p := m[row][col]
+		if p != 1 {
+			for j := col; j < len(m[row]); j++ {
+				m[row][j] /= p
+			}
+			trace.record(TraceScale, row, row, 1/p, m)
+		}
+		for k := range m {
+			if k == row || m[k][col] == 0 {
+				continue
+			}
+			factor := m[k][col]
+			for j := col; j < len(m[k]); j++ {
+				m[k][j] -= factor * m[row][j]
+			}
+			trace.record(TraceSubtract, k, row, factor, m)
+		}
+
+		pivots = append(pivots, col)
+		row++
+	}
+	return pivots
+}
+
+// ClassifySystem определяет ранг системы Ax = b и тип ее решения.
+// Для совместной системы возвращает частное решение, а при бесконечном
+// множестве решений - еще и базис ядра матрицы A, так что общее решение
+// x = Particular + t1*NullSpace[0] + t2*NullSpace[1] + ...
+// Преобразования приведения к ступенчатому виду записываются в trace, если он не nil
+func ClassifySystem(a Matrix, b Vector, trace *EliminationTrace) SystemSolution {
+	rows := len(a)
+	n := 0
+	if rows > 0 {
+		n = len(a[0])
+	}
+
+	m := Augment(a, b)
+	tol := rankTolerance(m)
+	pivots := reducedRowEchelon(m, n, tol, trace)
+
+	sol := SystemSolution{Rank: len(pivots), AugmentedRank: len(pivots)}
+	for r := sol.Rank; r < rows; r++ {
+		if math.Abs(m[r][n]) > tol {
+			sol.AugmentedRank++
+			sol.Kind = SystemInconsistent
+			return sol
+		}
+	}
+
+	sol.Particular = make([]float64, n)
+	isPivot := make([]bool, n)
+	for r, col := range pivots {
+		sol.Particular[col] = m[r][n]
+		isPivot[col] = true
+	}
+
+	for free := 0; free < n; free++ {
+		if isPivot[free] {
+			continue
+		}
+		v := make([]float64, n)
+		v[free] = 1
+		for r, col := range pivots {
+			v[col] = -m[r][free]
+		}
+		sol.NullSpace = append(sol.NullSpace, v)
+	}
+
+	if len(sol.NullSpace) > 0 {
+		sol.Kind = SystemInfinite
+	}
+	return sol
+}
+
+// IsRankDeficient проверяет, что ранг квадратной матрицы меньше ее порядка
+// с учетом погрешности округления
+func IsRankDeficient(a Matrix) bool {
+	m := make([][]float64, len(a))
+	for i := range a {
+		m[i] = append([]float64{}, a[i]...)
+	}
+	return len(reducedRowEchelon(m, len(a), rankTolerance(m), nil)) < len(a)
+}
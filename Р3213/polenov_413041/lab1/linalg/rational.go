@@ -0,0 +1,113 @@
+package linalg
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// floatToRat переводит число в дробь по его кратчайшей десятичной записи,
+// так что введенное 0.1 становится ровно 1/10
+func floatToRat(x float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(x, 'g', -1, 64))
+	return r
+}
+
+// ToRatMatrix переводит матрицу в рациональные числа
+func ToRatMatrix(a Matrix) [][]*big.Rat {
+	m := make([][]*big.Rat, len(a))
+	for i := range a {
+		m[i] = make([]*big.Rat, len(a[i]))
+		for j := range a[i] {
+			m[i][j] = floatToRat(a[i][j])
+		}
+	}
+	return m
+}
+
+// DeterminantRat вычисляет определитель точно методом Гаусса в дробях.
+// Возвращает также треугольную матрицу или nil, если матрица вырождена
+func DeterminantRat(a [][]*big.Rat) (*big.Rat, [][]*big.Rat) {
+	n := len(a)
+	m := make([][]*big.Rat, n)
+	for i := range a {
+		m[i] = make([]*big.Rat, len(a[i]))
+		for j := range a[i] {
+			m[i][j] = new(big.Rat).Set(a[i][j])
+		}
+	}
+
+	det := big.NewRat(1, 1)
+	for i := 0; i < n; i++ {
+		if GaussSolverForwardRat(i, m) {
+			det.Neg(det)
+		}
+		if m[i][i].Sign() == 0 {
+			return new(big.Rat), nil
+		}
+		det.Mul(det, m[i][i])
+	}
+	return det, m
+}
+
+// GaussSolverForwardRat шаг i прямого хода в дробях. В точной арифметике
+// достаточно любого ненулевого главного элемента. Возвращает true,
+// если строки были переставлены
+func GaussSolverForwardRat(i int, m [][]*big.Rat) bool {
+	n := len(m)
+	swapped := false
+	if m[i][i].Sign() == 0 {
+		for k := i + 1; k < n; k++ {
+			if m[k][i].Sign() != 0 {
+				m[i], m[k] = m[k], m[i]
+				swapped = true
+				break
+			}
+		}
+	}
+	if m[i][i].Sign() == 0 {
+		return swapped
+	}
+
+	factor := new(big.Rat)
+	tmp := new(big.Rat)
+	for k := i + 1; k < n; k++ {
+		if m[k][i].Sign() == 0 {
+			continue
+		}
+		factor.Quo(m[k][i], m[i][i])
+		for j := i; j < len(m[k]); j++ {
+			m[k][j].Sub(m[k][j], tmp.Mul(factor, m[i][j]))
+		}
+	}
+	return swapped
+}
+
+// GaussSolverBackwardRat обратный ход в дробях для правой части с номером col
+func GaussSolverBackwardRat(m [][]*big.Rat, col int) []*big.Rat {
+	n := len(m)
+	x := make([]*big.Rat, n)
+	tmp := new(big.Rat)
+	for i := n - 1; i >= 0; i-- {
+		sum := new(big.Rat).Set(m[i][n+col])
+		for j := i + 1; j < n; j++ {
+			sum.Sub(sum, tmp.Mul(m[i][j], x[j]))
+		}
+		x[i] = sum.Quo(sum, m[i][i])
+	}
+	return x
+}
+
+// CalculateDeltasRat точные невязки для расширенной матрицы n×(n+1)
+func CalculateDeltasRat(a [][]*big.Rat, x []*big.Rat) []*big.Rat {
+	n := len(a)
+	deltas := make([]*big.Rat, n)
+	tmp := new(big.Rat)
+	for i := 0; i < n; i++ {
+		left := new(big.Rat)
+		for j := 0; j < n; j++ {
+			left.Add(left, tmp.Mul(a[i][j], x[j]))
+		}
+		deltas[i] = left.Sub(a[i][n], left)
+	}
+	return deltas
+}
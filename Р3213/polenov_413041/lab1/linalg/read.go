@@ -0,0 +1,225 @@
+package linalg
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// maxEquations максимальное число уравнений в переопределенной системе
+const maxEquations = 10000
+
+// MaxFileDimension максимальный порядок плотной матрицы, читаемой из файла
+const MaxFileDimension = 2000
+
+// MaxPrintDimension матрицы и векторы большего размера не выводятся целиком
+const MaxPrintDimension = 20
+
+// maxBandDimension максимальный порядок ленточной системы
+const maxBandDimension = 1000000
+
+// IsBandFile проверяет, что файл записан в ленточном формате (первая строка "band n p q")
+func IsBandFile(path string) bool {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	header := strings.Fields(strings.SplitN(string(file), "\n", 2)[0])
+	return len(header) > 0 && header[0] == "band"
+}
+
+// ReadBandFromFile читает ленточную систему. Формат файла:
+// первая строка "band n p q" (порядок, число поддиагоналей и наддиагоналей),
+// затем p+q+1 строк с диагоналями от нижней к верхней
+// (диагональ со смещением d содержит n-|d| чисел) и строка правой части
+func ReadBandFromFile(path string) (*BandMatrix, Vector, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrIO, err)
+	}
+
+	var rows []string
+	for _, row := range strings.Split(string(file), "\n") {
+		if strings.TrimSpace(row) != "" {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("%w: file is empty", ErrParse)
+	}
+
+	header := strings.Fields(rows[0])
+	if len(header) != 4 || header[0] != "band" {
+		return nil, nil, parseError(lineNumber(1), "band header must be \"band n p q\"")
+	}
+	var dims [3]int
+	for i := range dims {
+		dims[i], err = strconv.Atoi(header[i+1])
+		if err != nil {
+			return nil, nil, parseError(lineNumber(1), "error converting band dimensions: %v", err)
+		}
+	}
+	n, p, q := dims[0], dims[1], dims[2]
+	if n < 2 || n > maxBandDimension {
+		return nil, nil, fmt.Errorf("%w: unsupported dimensions %v", ErrDimension, n)
+	}
+	if p < 0 || q < 0 || p >= n || q >= n {
+		return nil, nil, fmt.Errorf("%w: unsupported bandwidths %v %v", ErrDimension, p, q)
+	}
+	if len(rows) != p+q+3 {
+		return nil, nil, fmt.Errorf("%w: expected %d diagonals and right side, got %d rows", ErrParse, p+q+1, len(rows)-1)
+	}
+
+	bm := NewBandMatrix(n, p, q)
+	for d := -p; d <= q; d++ {
+		fields := strings.Fields(rows[d+p+1])
+		if len(fields) != n-abs(d) {
+			return nil, nil, fmt.Errorf("%w: diagonal with offset %d must contain %d numbers, got %d", ErrParse, d, n-abs(d), len(fields))
+		}
+		for k, field := range fields {
+			num, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: error parsing matrix number: %v", ErrParse, err)
+			}
+			i := k + max(0, -d)
+			bm.Set(i, i+d, num)
+		}
+	}
+
+	fields := strings.Fields(rows[len(rows)-1])
+	if len(fields) != n {
+		return nil, nil, fmt.Errorf("%w: right side must contain %d numbers, got %d", ErrParse, n, len(fields))
+	}
+	b := make([]float64, n)
+	for i, field := range fields {
+		b[i], err = strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: error parsing right side: %v", ErrParse, err)
+		}
+	}
+	return bm, b, nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// ReadMatrixFromFile читает расширенную матрицу системы из файла
+// (формат выбирается по расширению, см. readMatrixTokens).
+// Возвращает матрицу и число неизвестных
+func ReadMatrixFromFile(path string) (Matrix, int, error) {
+	tokens, n, err := readMatrixTokens(path, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matrix := make([][]float64, len(tokens))
+
+	for i, rowNums := range tokens {
+		matrix[i] = make([]float64, len(rowNums))
+		for j := range rowNums {
+			num, err := strconv.ParseFloat(rowNums[j].Text, 64)
+			if err != nil {
+				return nil, 0, parseError(rowNums[j].Pos, "error parsing matrix number %q", rowNums[j].Text)
+			}
+			matrix[i][j] = num
+		}
+	}
+
+	return matrix, n, nil
+}
+
+// ReadSquareMatrixFromFile читает квадратную матрицу без правых частей
+// (для поиска собственных значений)
+func ReadSquareMatrixFromFile(path string) (Matrix, error) {
+	tokens, n, err := readMatrixTokens(path, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != n {
+		return nil, fmt.Errorf("%w: eigenvalues are defined only for square matrices", ErrDimension)
+	}
+
+	matrix := make([][]float64, n)
+	for i, rowNums := range tokens {
+		matrix[i] = make([]float64, n)
+		for j := range rowNums {
+			num, err := strconv.ParseFloat(rowNums[j].Text, 64)
+			if err != nil {
+				return nil, parseError(rowNums[j].Pos, "error parsing matrix number %q", rowNums[j].Text)
+			}
+			matrix[i][j] = num
+		}
+	}
+	return matrix, nil
+}
+
+// IsComplexFile проверяет, есть ли в файле матрицы комплексные числа вида 3+4i
+func IsComplexFile(path string) bool {
+	tokens, _, err := readMatrixTokens(path, true)
+	if err != nil {
+		return false
+	}
+	for _, row := range tokens {
+		for _, tok := range row {
+			if _, err := strconv.ParseFloat(tok.Text, 64); err == nil {
+				continue
+			}
+			if _, err := strconv.ParseComplex(tok.Text, 128); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadComplexMatrixFromFile читает комплексную расширенную матрицу системы из файла
+func ReadComplexMatrixFromFile(path string) ([][]complex128, int, error) {
+	tokens, n, err := readMatrixTokens(path, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matrix := make([][]complex128, len(tokens))
+
+	for i, rowNums := range tokens {
+		matrix[i] = make([]complex128, len(rowNums))
+		for j := range rowNums {
+			num, err := strconv.ParseComplex(rowNums[j].Text, 128)
+			if err != nil {
+				return nil, 0, parseError(rowNums[j].Pos, "error parsing complex matrix number %q", rowNums[j].Text)
+			}
+			matrix[i][j] = num
+		}
+	}
+
+	return matrix, n, nil
+}
+
+// ReadRatMatrixFromFile читает матрицу из файла в точные рациональные числа
+func ReadRatMatrixFromFile(path string) ([][]*big.Rat, error) {
+	tokens, _, err := readMatrixTokens(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]*big.Rat, len(tokens))
+
+	for i, rowNums := range tokens {
+		matrix[i] = make([]*big.Rat, len(rowNums))
+		for j := range rowNums {
+			num, ok := new(big.Rat).SetString(rowNums[j].Text)
+			if !ok {
+				return nil, parseError(rowNums[j].Pos, "error parsing matrix number %q", rowNums[j].Text)
+			}
+			matrix[i][j] = num
+		}
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,75 @@
+package linalg
+
+import "math"
+
+// maxRefinementSteps ограничение числа шагов уточнения
+const maxRefinementSteps = 10
+
+// RefinementResult результат итерационного уточнения решения
+type RefinementResult struct {
+	X []float64
+	// ResidualNorms норма невязки до уточнения и после каждого шага
+	ResidualNorms []float64
+}
+
+// twoSum точная сумма a + b = s + e (алгоритм Кнута)
+func twoSum(a, b float64) (float64, float64) {
+	s := a + b
+	z := s - a
+	return s, (a - (s - z)) + (b - z)
+}
+
+// twoProduct точное произведение a*b = p + e через FMA
+func twoProduct(a, b float64) (float64, float64) {
+	p := a * b
+	return p, math.FMA(a, b, -p)
+}
+
+// CompensatedResidual вычисляет невязку b - Ax с компенсацией ошибок
+// округления (алгоритм Dot2 Огиты-Румпа-Оиши): результат получается
+// таким, как если бы вычисления шли с удвоенной точностью
+func CompensatedResidual(a Matrix, b Vector, x Vector) Vector {
+	r := make([]float64, len(a))
+	for i := range a {
+		sum, comp := b[i], 0.0
+		for j := range x {
+			p, pe := twoProduct(-a[i][j], x[j])
+			var se float64
+			sum, se = twoSum(sum, p)
+			comp += pe + se
+		}
+		r[i] = sum + comp
+	}
+	return r
+}
+
+// IterativeRefinement уточняет решение системы Ax = b: на каждом шаге
+// решается A·d = r с уже построенным разложением и x заменяется на x + d.
+// Процесс останавливается, когда норма невязки перестает убывать
+func IterativeRefinement(a Matrix, b Vector, f Factorization) RefinementResult {
+	x := f.Solve(b)
+	r := CompensatedResidual(a, b, x)
+	res := RefinementResult{X: x, ResidualNorms: []float64{VectorNorm(r)}}
+
+	for step := 0; step < maxRefinementSteps; step++ {
+		prevNorm := res.ResidualNorms[len(res.ResidualNorms)-1]
+		if prevNorm == 0 {
+			break
+		}
+
+		d := f.Solve(r)
+		next := make([]float64, len(x))
+		for i := range x {
+			next[i] = x[i] + d[i]
+		}
+		nextR := CompensatedResidual(a, b, next)
+		norm := VectorNorm(nextR)
+		res.ResidualNorms = append(res.ResidualNorms, norm)
+		if norm >= prevNorm {
+			break
+		}
+		x, r = next, nextR
+		res.X = x
+	}
+	return res
+}
@@ -0,0 +1,213 @@
+package linalg
+
+// PivotStrategy стратегия выбора главного элемента в методе Гаусса
+type PivotStrategy int
+
+const (
+	// PivotNone строки переставляются только при нулевом диагональном элементе
+	PivotNone PivotStrategy = iota
+	// PivotPartial выбор максимального по модулю элемента в столбце
+	PivotPartial
+	// PivotComplete выбор максимального по модулю элемента во всей подматрице
+	// с перестановкой столбцов (и неизвестных)
+	PivotComplete
+)
+
+func (p PivotStrategy) String() string {
+	switch p {
+	case PivotPartial:
+		return "частичный (по столбцу)"
+	case PivotComplete:
+		return "полный (по всей подматрице)"
+	default:
+		return "без выбора главного элемента"
+	}
+}
+
+// Determinant вычисляет определитель матрицы любого размера методом Гаусса.
+// Кроме определителя возвращает треугольную матрицу и порядок неизвестных,
+// который меняется при перестановке столбцов (полный выбор главного элемента).
+// Для вырожденной матрицы возвращает нулевой определитель и ErrSingular.
+// Если trace не nil, в него записываются все элементарные преобразования
+func Determinant(a Matrix, pivot PivotStrategy, trace *EliminationTrace) (float64, Matrix, []int, error) {
+	g, err := NewGaussElimination(a, pivot, trace)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return g.det, g.M, g.Order, nil
+}
+
+// GaussElimination прямой ход метода Гаусса для расширенной матрицы:
+// треугольная матрица, порядок неизвестных и преобразования строк, которые
+// повторяются для новой правой части. Как разложение используется для
+// итерационного уточнения решения
+type GaussElimination struct {
+	M     Matrix
+	Order []int
+	// ops перестановки и вычитания строк без снимков матрицы
+	ops []TraceStep
+	det float64
+}
+
+// NewGaussElimination выполняет прямой ход, trace (если не nil) получает
+// все элементарные преобразования
+func NewGaussElimination(a Matrix, pivot PivotStrategy, trace *EliminationTrace) (*GaussElimination, error) {
+	g := &GaussElimination{}
+	record := func(op TraceOp, row, other int, factor float64, m [][]float64) {
+		if op != TraceInitial {
+			g.ops = append(g.ops, TraceStep{Op: op, Row: row, Other: other, Factor: factor})
+		}
+		trace.record(op, row, other, factor, m)
+	}
+	m, order, det, err := eliminate(a, pivot, record)
+	if err != nil {
+		return nil, err
+	}
+	g.M, g.Order, g.det = m, order, det
+	return g, nil
+}
+
+// Solve повторяет преобразования строк для правой части b и выполняет
+// обратный ход; перестановки столбцов учитываются порядком неизвестных
+func (g *GaussElimination) Solve(b Vector) Vector {
+	y := append(Vector{}, b...)
+	for _, op := range g.ops {
+		switch op.Op {
+		case TraceSwapRows:
+			y[op.Row], y[op.Other] = y[op.Other], y[op.Row]
+		case TraceSubtract:
+			y[op.Row] -= op.Factor * y[op.Other]
+		}
+	}
+
+	n := len(g.M)
+	z := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for j := i + 1; j < n; j++ {
+			sum -= g.M[i][j] * z[j]
+		}
+		z[i] = sum / g.M[i][i]
+	}
+	x := make([]float64, n)
+	for i := range z {
+		x[g.Order[i]] = z[i]
+	}
+	return x
+}
+
+// Determinant определитель матрицы коэффициентов
+func (g *GaussElimination) Determinant() float64 {
+	return g.det
+}
+
+// selectPivot возвращает строку и столбец главного элемента для шага i
+func selectPivot[T Scalar](i int, m [][]T, pivot PivotStrategy) (int, int) {
+	n := len(m)
+	row, col := i, i
+
+	switch pivot {
+	case PivotPartial:
+		for k := i + 1; k < n; k++ {
+			if scalarAbs(m[k][i]) > scalarAbs(m[row][i]) {
+				row = k
+			}
+		}
+	case PivotComplete:
+		for k := i; k < n; k++ {
+			for j := i; j < n; j++ {
+				if scalarAbs(m[k][j]) > scalarAbs(m[row][col]) {
+					row, col = k, j
+				}
+			}
+		}
+	default:
+		// перестановка строк, если на главной диагонали 0
+		if m[i][i] == 0 {
+			for k := i + 1; k < n; k++ {
+				if m[k][i] != 0 {
+					row = k
+					break
+				}
+			}
+		}
+	}
+	return row, col
+}
+
+// GaussSolverForward выполняет шаг i прямого хода: выбирает главный элемент,
+// переставляет строки (и столбцы, запоминая порядок неизвестных в order)
+// и исключает i-ю неизвестную из нижележащих строк.
+// Возвращает множитель знака определителя (1 или -1) после перестановок
+func GaussSolverForward(i int, m Matrix, pivot PivotStrategy, order []int, trace *EliminationTrace) float64 {
+	return eliminateStep(i, m, pivot, order, trace.record)
+}
+
+// eliminateStep шаг прямого хода для любого типа чисел, record (если не nil)
+// вызывается после каждого элементарного преобразования
+func eliminateStep[T Scalar](i int, m [][]T, pivot PivotStrategy, order []int, record func(TraceOp, int, int, T, [][]T)) T {
+	n := len(m)
+	var sign T = 1
+	if record == nil {
+		record = func(TraceOp, int, int, T, [][]T) {}
+	}
+
+	row, col := selectPivot(i, m, pivot)
+	if row != i {
+		m[i], m[row] = m[row], m[i]
+		sign = -sign
+		record(TraceSwapRows, i, row, 0, m)
+	}
+	if col != i {
+		for k := 0; k < n; k++ {
+			m[k][i], m[k][col] = m[k][col], m[k][i]
+		}
+		order[i], order[col] = order[col], order[i]
+		sign = -sign
+		record(TraceSwapColumns, i, col, 0, m)
+	}
+	if m[i][i] == 0 {
+		return sign
+	}
+
+	for k := i + 1; k < n; k++ {
+		factor := m[k][i] / m[i][i]
+		if factor == 0 {
+			continue
+		}
+		m[k][i] = 0
+		for j := i + 1; j < len(m[k]); j++ {
+			m[k][j] -= factor * m[i][j]
+		}
+		record(TraceSubtract, k, i, factor, m)
+	}
+	return sign
+}
+
+// GaussSolverBackward обратный ход метода Гаусса для правой части с номером col
+// (0 - первый столбец после коэффициентов), решения возвращаются
+// в исходном порядке неизвестных
+func GaussSolverBackward(m Matrix, order []int, col int) Vector {
+	n := len(m)
+	y := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+
+		sum := m[i][n+col]
+		for j := i + 1; j < n; j++ {
+			sum -= m[i][j] * y[j]
+		}
+		y[i] = sum / m[i][i]
+	}
+
+	x := make([]float64, n)
+	for i := range y {
+		x[order[i]] = y[i]
+	}
+	return x
+}
+
+// CalculateDeltas вектор невязок b - Ax для расширенной матрицы,
+// число неизвестных определяется длиной x (матрица может быть прямоугольной)
+func CalculateDeltas(a Matrix, x Vector) Vector {
+	return Residual(a, x)
+}
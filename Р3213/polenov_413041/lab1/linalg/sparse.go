@@ -0,0 +1,208 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CSRMatrix разреженная матрица в формате CSR (сжатое хранение строк):
+// ненулевые элементы строки i лежат в Values[RowPtr[i]:RowPtr[i+1]],
+// их столбцы - в ColInd с тем же индексом
+type CSRMatrix struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColInd     []int
+	Values     []float64
+}
+
+// NewCSRFromTriplets собирает CSR-матрицу из троек (i, j, v).
+// Повторяющиеся позиции суммируются, столбцы в строке упорядочиваются
+func NewCSRFromTriplets(rows, cols int, is, js []int, vs Vector) *CSRMatrix {
+	m := &CSRMatrix{Rows: rows, Cols: cols, RowPtr: make([]int, rows+1)}
+	for _, i := range is {
+		m.RowPtr[i+1]++
+	}
+	for i := 0; i < rows; i++ {
+		m.RowPtr[i+1] += m.RowPtr[i]
+	}
+
+	colInd := make([]int, len(vs))
+	values := make([]float64, len(vs))
+	next := append([]int{}, m.RowPtr[:rows]...)
+	for k := range vs {
+		pos := next[is[k]]
+		colInd[pos] = js[k]
+		values[pos] = vs[k]
+		next[is[k]]++
+	}
+
+	// сортировка столбцов и объединение повторов в каждой строке
+	m.ColInd = make([]int, 0, len(vs))
+	m.Values = make([]float64, 0, len(vs))
+	for i := 0; i < rows; i++ {
+		start, end := m.RowPtr[i], m.RowPtr[i+1]
+		idx := make([]int, end-start)
+		for k := range idx {
+			idx[k] = start + k
+		}
+		sort.Slice(idx, func(a, b int) bool { return colInd[idx[a]] < colInd[idx[b]] })
+
+		m.RowPtr[i] = len(m.Values)
+		for _, k := range idx {
+			last := len(m.ColInd) - 1
+			if last >= m.RowPtr[i] && m.ColInd[last] == colInd[k] {
+				m.Values[last] += values[k]
+				continue
+			}
+			m.ColInd = append(m.ColInd, colInd[k])
+			m.Values = append(m.Values, values[k])
+		}
+	}
+	m.RowPtr[rows] = len(m.Values)
+	return m
+}
+
+// DenseToCSR переводит плотную матрицу коэффициентов в CSR, пропуская нули
+func DenseToCSR(a Matrix) *CSRMatrix {
+	m := &CSRMatrix{Rows: len(a), RowPtr: make([]int, len(a)+1)}
+	if len(a) > 0 {
+		m.Cols = len(a[0])
+	}
+	for i := range a {
+		for j, v := range a[i] {
+			if v != 0 {
+				m.ColInd = append(m.ColInd, j)
+				m.Values = append(m.Values, v)
+			}
+		}
+		m.RowPtr[i+1] = len(m.Values)
+	}
+	return m
+}
+
+// NonZeros число хранимых элементов
+func (m *CSRMatrix) NonZeros() int {
+	return len(m.Values)
+}
+
+// MulVec произведение матрицы на вектор
+func (m *CSRMatrix) MulVec(x Vector) Vector {
+	y := make([]float64, m.Rows)
+	for i := 0; i < m.Rows; i++ {
+		sum := 0.0
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			sum += m.Values[k] * x[m.ColInd[k]]
+		}
+		y[i] = sum
+	}
+	return y
+}
+
+// Residual вектор невязок b - Ax
+func (m *CSRMatrix) Residual(x Vector, b Vector) Vector {
+	ax := m.MulVec(x)
+	for i := range ax {
+		ax[i] = b[i] - ax[i]
+	}
+	return ax
+}
+
+// Diagonal главная диагональ матрицы
+func (m *CSRMatrix) Diagonal() Vector {
+	d := make([]float64, min(m.Rows, m.Cols))
+	for i := range d {
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			if m.ColInd[k] == i {
+				d[i] = m.Values[k]
+				break
+			}
+		}
+	}
+	return d
+}
+
+// At элемент (i, j) двоичным поиском по столбцам строки
+func (m *CSRMatrix) At(i, j int) float64 {
+	start, end := m.RowPtr[i], m.RowPtr[i+1]
+	k := start + sort.SearchInts(m.ColInd[start:end], j)
+	if k < end && m.ColInd[k] == j {
+		return m.Values[k]
+	}
+	return 0
+}
+
+// IsSymmetric проверяет симметричность с относительным допуском
+func (m *CSRMatrix) IsSymmetric() bool {
+	if m.Rows != m.Cols {
+		return false
+	}
+	for i := 0; i < m.Rows; i++ {
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			j := m.ColInd[k]
+			v, t := m.Values[k], m.At(j, i)
+			scale := math.Max(math.Max(math.Abs(v), math.Abs(t)), 1)
+			if math.Abs(v-t) > symmetryTolerance*scale {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SparseGaussSeidel метод Гаусса-Зейделя для разреженной матрицы.
+// Для экономии памяти сохраняется только норма погрешности на каждой итерации
+func SparseGaussSeidel(m *CSRMatrix, b Vector, eps float64, maxIter int) (IterativeResult, error) {
+	diag := m.Diagonal()
+	for i, d := range diag {
+		if d == 0 {
+			return IterativeResult{}, fmt.Errorf("%w: zero element on the main diagonal in row %d", ErrSingular, i+1)
+		}
+	}
+
+	x := make([]float64, m.Rows)
+	res := IterativeResult{}
+	for res.Iterations < maxIter {
+		maximum := 0.0
+		for i := 0; i < m.Rows; i++ {
+			sum := b[i]
+			for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+				if j := m.ColInd[k]; j != i {
+					sum -= m.Values[k] * x[j]
+				}
+			}
+			next := sum / diag[i]
+			maximum = math.Max(maximum, math.Abs(next-x[i]))
+			x[i] = next
+		}
+		res.Iterations++
+		res.ErrorNorms = append(res.ErrorNorms, maximum)
+		if maximum < eps {
+			res.X = x
+			return res, nil
+		}
+	}
+	res.X = x
+	return res, fmt.Errorf("%w in %d iterations", ErrNoConvergence, maxIter)
+}
+
+// dot скалярное произведение
+func dot(x []float64, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// Dense плотное представление матрицы
+func (m *CSRMatrix) Dense() Matrix {
+	a := make([][]float64, m.Rows)
+	for i := range a {
+		a[i] = make([]float64, m.Cols)
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			a[i][m.ColInd[k]] = m.Values[k]
+		}
+	}
+	return a
+}
@@ -0,0 +1,176 @@
+package linalg
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TraceOp вид элементарного преобразования
+type TraceOp int
+
+const (
+	// TraceInitial исходная матрица
+	TraceInitial TraceOp = iota
+	// TraceSwapRows перестановка строк Row и Other
+	TraceSwapRows
+	// TraceSwapColumns перестановка столбцов Row и Other (полный выбор главного элемента)
+	TraceSwapColumns
+	// TraceScale умножение строки Row на Factor
+	TraceScale
+	// TraceSubtract вычитание из строки Row строки Other, умноженной на Factor
+	TraceSubtract
+)
+
+// TraceStep одно преобразование и матрица после него
+type TraceStep struct {
+	Op     TraceOp
+	Row    int
+	Other  int
+	Factor float64
+	Matrix [][]float64
+}
+
+// EliminationTrace запись всех элементарных преобразований метода Гаусса.
+// Методы записи безопасно вызывать у nil - тогда ничего не записывается
+type EliminationTrace struct {
+	Steps []TraceStep
+}
+
+func (t *EliminationTrace) record(op TraceOp, row, other int, factor float64, m [][]float64) {
+	if t == nil {
+		return
+	}
+	snapshot := make([][]float64, len(m))
+	for i := range m {
+		snapshot[i] = append([]float64{}, m[i]...)
+	}
+	t.Steps = append(t.Steps, TraceStep{Op: op, Row: row, Other: other, Factor: factor, Matrix: snapshot})
+}
+
+// describe описание шага, в LaTeX - в виде формулы
+func (s TraceStep) describe(latex bool) string {
+	r, o := s.Row+1, s.Other+1
+	f := strconv.FormatFloat(s.Factor, 'g', 6, 64)
+	if latex {
+		switch s.Op {
+		case TraceSwapRows:
+			return fmt.Sprintf("$R_{%d} \\leftrightarrow R_{%d}$", r, o)
+		case TraceSwapColumns:
+			return fmt.Sprintf("$C_{%d} \\leftrightarrow C_{%d}$", r, o)
+		case TraceScale:
+			return fmt.Sprintf("$R_{%d} \\gets %s \\cdot R_{%d}$", r, f, r)
+		case TraceSubtract:
+			return fmt.Sprintf("$R_{%d} \\gets R_{%d} - (%s) \\cdot R_{%d}$", r, r, f, o)
+		default:
+			return "исходная матрица"
+		}
+	}
+	switch s.Op {
+	case TraceSwapRows:
+		return fmt.Sprintf("перестановка строк R%d <-> R%d", r, o)
+	case TraceSwapColumns:
+		return fmt.Sprintf("перестановка столбцов C%d <-> C%d", r, o)
+	case TraceScale:
+		return fmt.Sprintf("R%d = %s * R%d", r, f, r)
+	case TraceSubtract:
+		return fmt.Sprintf("R%d = R%d - (%s) * R%d", r, r, f, o)
+	default:
+		return "исходная матрица"
+	}
+}
+
+// formatTraceNumber короткая запись числа для таблиц
+func formatTraceNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// Text трасса в виде простого текста
+func (t *EliminationTrace) Text() string {
+	var sb strings.Builder
+	for k, step := range t.Steps {
+		fmt.Fprintf(&sb, "Шаг %d: %s\n", k, step.describe(false))
+		for _, row := range step.Matrix {
+			for _, v := range row {
+				fmt.Fprintf(&sb, "%12s ", formatTraceNumber(v))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Markdown трасса в виде таблиц Markdown
+func (t *EliminationTrace) Markdown() string {
+	var sb strings.Builder
+	for k, step := range t.Steps {
+		fmt.Fprintf(&sb, "**Шаг %d.** %s\n\n", k, step.describe(false))
+		n := len(step.Matrix)
+		if n == 0 {
+			continue
+		}
+
+		sb.WriteString("| |")
+		for j := range step.Matrix[0] {
+			if j < n {
+				fmt.Fprintf(&sb, " x%d |", j+1)
+			} else {
+				fmt.Fprintf(&sb, " b%d |", j-n+1)
+			}
+		}
+		sb.WriteString("\n|---|")
+		sb.WriteString(strings.Repeat("---|", len(step.Matrix[0])))
+		sb.WriteString("\n")
+		for i, row := range step.Matrix {
+			fmt.Fprintf(&sb, "| R%d |", i+1)
+			for _, v := range row {
+				fmt.Fprintf(&sb, " %s |", formatTraceNumber(v))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// LaTeX трасса в виде блоков pmatrix (требуется пакет amsmath)
+func (t *EliminationTrace) LaTeX() string {
+	var sb strings.Builder
+	for k, step := range t.Steps {
+		fmt.Fprintf(&sb, "Шаг %d: %s\n\\[\n\\begin{pmatrix}\n", k, step.describe(true))
+		for i, row := range step.Matrix {
+			cells := make([]string, len(row))
+			for j, v := range row {
+				cells[j] = formatTraceNumber(v)
+			}
+			sb.WriteString(strings.Join(cells, " & "))
+			if i < len(step.Matrix)-1 {
+				sb.WriteString(" \\\\")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\\end{pmatrix}\n\\]\n\n")
+	}
+	return sb.String()
+}
+
+// Export сохраняет трассу в файл в формате "text", "md" или "tex"
+func (t *EliminationTrace) Export(path string, format string) error {
+	var content string
+	switch format {
+	case "text":
+		content = t.Text()
+	case "md":
+		content = t.Markdown()
+	case "tex":
+		content = t.LaTeX()
+	default:
+		return fmt.Errorf("%w: unknown trace format: %v", ErrInvalidArgument, format)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("%w: writing trace: %w", ErrIO, err)
+	}
+	return nil
+}
@@ -1,5 +1,11 @@
 package main
 
+import "os"
+
 func main() {
+	// с аргументами командной строки - неинтерактивный режим
+	if len(os.Args) > 1 {
+		os.Exit(RunCLI(os.Args[1:]))
+	}
 	CallUI()
 }
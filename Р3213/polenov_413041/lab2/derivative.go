@@ -0,0 +1,679 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Derivative символьная производная выражения по x с упрощением результата
+func Derivative(e Expr) Expr {
+	return Simplify(derive(e))
+}
+
+// derive дифференцирует выражение по правилам без упрощения
+func derive(e Expr) Expr {
+	switch n := e.(type) {
+	case Variable:
+		return Number{1}
+	case Unary:
+		return Unary{derive(n.X)}
+	case Binary:
+		dl, dr := derive(n.L), derive(n.R)
+		switch n.Op {
+		case '+', '-':
+			return Binary{n.Op, dl, dr}
+		case '*':
+			return Binary{'+', Binary{'*', dl, n.R}, Binary{'*', n.L, dr}}
+		case '/':
+			return Binary{'/', Binary{'-', Binary{'*', dl, n.R}, Binary{'*', n.L, dr}}, Binary{'^', n.R, Number{2}}}
+		}
+		if !dependsOnX(n.R) {
+			// (u^c)' = c*u^(c-1)*u'
+			return Binary{'*', Binary{'*', n.R, Binary{'^', n.L, Binary{'-', n.R, Number{1}}}}, dl}
+		}
+		if !dependsOnX(n.L) {
+			// (c^v)' = c^v*ln(c)*v'
+			return Binary{'*', Binary{'*', n, Call{"ln", []Expr{n.L}}}, dr}
+		}
+		// (u^v)' = u^v*(v'*ln(u) + v*u'/u)
+		return Binary{'*', n, Binary{'+', Binary{'*', dr, Call{"ln", []Expr{n.L}}}, Binary{'/', Binary{'*', n.R, dl}, n.L}}}
+	case Call:
+		if n.Name == "pow" {
+			return derive(Binary{'^', n.Args[0], n.Args[1]})
+		}
+		u := n.Args[0]
+		du := derive(u)
+		switch n.Name {
+		case "sin":
+			return Binary{'*', Call{"cos", n.Args}, du}
+		case "cos":
+			return Binary{'*', Unary{Call{"sin", n.Args}}, du}
+		case "exp":
+			return Binary{'*', n, du}
+		case "ln":
+			return Binary{'/', du, u}
+		case "sqrt":
+			return Binary{'/', du, Binary{'*', Number{2}, n}}
+		case "abs":
+			return Binary{'*', Binary{'/', u, n}, du}
+		}
+	}
+	// числа и константы
+	return Number{0}
+}
+
+// Simplify упрощает выражение, пока оно меняется
+func Simplify(e Expr) Expr {
+	for i := 0; i < 16; i++ {
+		s := simplifyOnce(e)
+		if reflect.DeepEqual(s, e) {
+			return s
+		}
+		e = s
+	}
+	return e
+}
+
+// Сумма раскладывается на слагаемые, а каждое слагаемое - на числовой
+// коэффициент p/q и степени множителей. Множители и слагаемые сортируются,
+// поэтому подобные члены записываются одинаково и приводятся
+
+// power множитель base^exp
+type power struct {
+	base, exp Expr
+}
+
+// product произведение p/q * base1^exp1 * base2^exp2 * ...
+type product struct {
+	p, q    float64
+	factors []power
+}
+
+func simplifyOnce(e Expr) Expr {
+	switch n := e.(type) {
+	case Unary:
+		return simplifySum(Unary{simplifyOnce(n.X)})
+	case Binary:
+		l, r := simplifyOnce(n.L), simplifyOnce(n.R)
+		switch n.Op {
+		case '+', '-':
+			return simplifySum(Binary{n.Op, l, r})
+		case '*', '/':
+			t := toProduct(Binary{n.Op, l, r})
+			if t.scaledSum() {
+				return simplifySum(t.expr())
+			}
+			return t.expr()
+		}
+		return simplifyPower(l, r)
+	case Call:
+		args := make([]Expr, len(n.Args))
+		for i, a := range n.Args {
+			args[i] = simplifyOnce(a)
+		}
+		return simplifyCall(n.Name, args)
+	}
+	return e
+}
+
+// simplifySum приводит подобные слагаемые, складывает дроби с одинаковым
+// знаменателем и упорядочивает слагаемые по убыванию степени x
+func simplifySum(e Expr) Expr {
+	var terms []product
+	collectTerms(e, 1, 1, &terms)
+	terms = combineFractions(combineLike(terms))
+	sort.SliceStable(terms, func(i, j int) bool { return termLess(terms[i], terms[j]) })
+
+	if len(terms) == 0 {
+		return Number{0}
+	}
+	res := terms[0].expr()
+	for _, t := range terms[1:] {
+		if t.p < 0 {
+			t.p = -t.p
+			res = Binary{'-', res, t.expr()}
+		} else {
+			res = Binary{'+', res, t.expr()}
+		}
+	}
+	return res
+}
+
+// collectTerms раскладывает сумму на слагаемые, умножая их на p/q.
+// Числовой множитель перед суммой раскрывается: 2*(x + 1) = 2*x + 2
+func collectTerms(e Expr, p, q float64, terms *[]product) {
+	switch n := e.(type) {
+	case Unary:
+		collectTerms(n.X, -p, q, terms)
+		return
+	case Binary:
+		switch n.Op {
+		case '+':
+			collectTerms(n.L, p, q, terms)
+			collectTerms(n.R, p, q, terms)
+			return
+		case '-':
+			collectTerms(n.L, p, q, terms)
+			collectTerms(n.R, -p, q, terms)
+			return
+		}
+	}
+	t := toProduct(e)
+	if t.scaledSum() {
+		collectTerms(t.factors[0].base, p*t.p, q*t.q, terms)
+		return
+	}
+	t.p, t.q = reduce(t.p*p, t.q*q)
+	*terms = append(*terms, t)
+}
+
+// scaledSum проверяет, что произведение - сумма с числовым коэффициентом
+func (t product) scaledSum() bool {
+	return len(t.factors) == 1 && isNumber(t.factors[0].exp, 1) && precedence(t.factors[0].base) == precSum
+}
+
+// combineLike складывает коэффициенты слагаемых с одинаковыми множителями.
+// Сумма, равная нулю, отбрасывается, только если слагаемое определено при
+// всех x: sqrt(x) - sqrt(x) не определено при x < 0 и остается как есть
+func combineLike(terms []product) []product {
+	var order []string
+	groups := map[string][]product{}
+	for _, t := range terms {
+		key := t.key()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	var res []product
+	for _, key := range order {
+		group := groups[key]
+		sum := group[0]
+		for _, t := range group[1:] {
+			sum.p, sum.q = reduce(sum.p*t.q+t.p*sum.q, sum.q*t.q)
+		}
+		switch {
+		case sum.p != 0:
+			res = append(res, sum)
+		case !definedEverywhere(product{1, 1, sum.factors}.expr()):
+			res = append(res, group...)
+		}
+	}
+	return res
+}
+
+// combineFractions приводит к общему знаменателю слагаемые, у которых
+// знаменатели совпадают: a/d + b/d = (a + b)/d
+func combineFractions(terms []product) []product {
+	var res []product
+	groups := map[string][]product{}
+	var order []string
+	for _, t := range terms {
+		_, den := t.split()
+		if len(den.factors) == 0 {
+			res = append(res, t)
+			continue
+		}
+		key := den.expr().String()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	for _, key := range order {
+		group := groups[key]
+		if len(group) == 1 {
+			res = append(res, group[0])
+			continue
+		}
+		var sum Expr
+		var den product
+		for _, t := range group {
+			num, d := t.split()
+			den = d
+			if sum == nil {
+				sum = num.expr()
+			} else {
+				sum = Binary{'+', sum, num.expr()}
+			}
+		}
+		sum = Simplify(sum)
+		if isNumber(sum, 0) && !definedEverywhere(Binary{'/', Number{1}, den.expr()}) {
+			// дроби взаимно уничтожаются, но знаменатель может обращаться в ноль
+			res = append(res, group...)
+			continue
+		}
+		res = append(res, toProduct(Binary{'/', sum, den.expr()}))
+	}
+	return removeZeros(res)
+}
+
+func removeZeros(terms []product) []product {
+	res := terms[:0]
+	for _, t := range terms {
+		if t.p != 0 {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// termLess порядок слагаемых: степени x по убыванию, затем остальные по
+// записи, свободный член в конце
+func termLess(a, b product) bool {
+	ra, da := a.rank()
+	rb, db := b.rank()
+	if ra != rb {
+		return ra < rb
+	}
+	if ra == 0 {
+		return da > db
+	}
+	return a.key() < b.key()
+}
+
+// rank группа слагаемого для сортировки и степень x, если это c*x^k
+func (t product) rank() (int, float64) {
+	if len(t.factors) == 0 {
+		return 2, 0
+	}
+	if len(t.factors) == 1 {
+		f := t.factors[0]
+		if k, ok := f.exp.(Number); ok && k.Value > 0 && f.base == (Variable{}) {
+			return 0, k.Value
+		}
+	}
+	return 1, 0
+}
+
+// key запись слагаемого без числового коэффициента
+func (t product) key() string {
+	return product{1, 1, t.factors}.expr().String()
+}
+
+// split разделяет произведение на числитель и знаменатель с положительными
+// показателями, числовой коэффициент остается в числителе
+func (t product) split() (product, product) {
+	num := product{p: t.p, q: t.q}
+	den := product{p: 1, q: 1}
+	for _, f := range t.factors {
+		if c, ok := f.exp.(Number); ok && c.Value < 0 {
+			den.factors = append(den.factors, power{f.base, Number{-c.Value}})
+		} else {
+			num.factors = append(num.factors, f)
+		}
+	}
+	return num, den
+}
+
+// toProduct раскладывает выражение на коэффициент и степени множителей
+func toProduct(e Expr) product {
+	t := product{p: 1, q: 1}
+	collectFactors(e, false, &t)
+	return t.normalize()
+}
+
+// collectFactors добавляет множители выражения, inverse - в знаменатель
+func collectFactors(e Expr, inverse bool, t *product) {
+	switch n := e.(type) {
+	case Number:
+		switch {
+		case !inverse:
+			t.p *= n.Value
+			return
+		case n.Value != 0:
+			t.q *= n.Value
+			return
+		}
+	case Unary:
+		t.p = -t.p
+		collectFactors(n.X, inverse, t)
+		return
+	case Binary:
+		switch n.Op {
+		case '*':
+			collectFactors(n.L, inverse, t)
+			collectFactors(n.R, inverse, t)
+			return
+		case '/':
+			collectFactors(n.L, inverse, t)
+			collectFactors(n.R, !inverse, t)
+			return
+		case '^':
+			exp := n.R
+			if inverse {
+				exp = simplifyOnce(Unary{exp})
+			}
+			t.factors = append(t.factors, power{n.L, exp})
+			return
+		}
+	}
+	var exp Expr = Number{1}
+	if inverse {
+		exp = Number{-1}
+	}
+	t.factors = append(t.factors, power{e, exp})
+}
+
+// normalize сокращает коэффициент, объединяет степени одного основания
+// и сортирует множители
+func (t product) normalize() product {
+	t.p, t.q = reduce(t.p, t.q)
+	if t.p == 0 {
+		return product{0, 1, nil}
+	}
+	var order []string
+	groups := map[string][]power{}
+	for _, f := range t.factors {
+		key := f.base.String()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], f)
+	}
+	factors := make([]power, 0, len(t.factors))
+	for _, key := range order {
+		factors = append(factors, combinePowers(groups[key])...)
+	}
+	sort.SliceStable(factors, func(i, j int) bool {
+		ri, rj := factorRank(factors[i].base), factorRank(factors[j].base)
+		if ri != rj {
+			return ri < rj
+		}
+		return factors[i].base.String() < factors[j].base.String()
+	})
+	t.factors = factors
+	return t
+}
+
+// combinePowers складывает показатели степеней одного основания, если это
+// не меняет область определения: x^3/x остается дробью, потому что x = 0
+// не входит в область определения, а x^0.5*x^0.5 не превращается в x
+func combinePowers(powers []power) []power {
+	base := powers[0].base
+	var pos, neg []float64
+	var sum Expr = Number{0}
+	symbolic := false
+	for _, f := range powers {
+		sum = Binary{'+', sum, f.exp}
+		c, ok := f.exp.(Number)
+		switch {
+		case !ok:
+			symbolic = true
+		case c.Value > 0:
+			pos = append(pos, c.Value)
+		default:
+			neg = append(neg, c.Value)
+		}
+	}
+	if symbolic {
+		exp := Simplify(sum)
+		if isNumber(exp, 0) && !nonZero(base) {
+			return powers
+		}
+		return powersOf(base, []float64{}, exp)
+	}
+
+	pos, neg = addExponents(pos), addExponents(neg)
+	if len(pos) == 1 && len(neg) == 1 {
+		total := pos[0] + neg[0]
+		if (total < 0 || nonZero(base)) && !(isInteger(total) && (!isInteger(pos[0]) || !isInteger(neg[0]))) {
+			pos, neg = []float64{total}, nil
+		}
+	}
+	return powersOf(base, append(pos, neg...), nil)
+}
+
+// addExponents складывает показатели одного знака, если дробные показатели
+// не дают в сумме целый
+func addExponents(exps []float64) []float64 {
+	if len(exps) < 2 {
+		return exps
+	}
+	sum, allInt := 0.0, true
+	for _, e := range exps {
+		sum += e
+		allInt = allInt && isInteger(e)
+	}
+	if allInt || !isInteger(sum) {
+		return []float64{sum}
+	}
+	return exps
+}
+
+// powersOf множители base^e для числовых показателей и base^exp для символьного
+func powersOf(base Expr, exps []float64, exp Expr) []power {
+	var res []power
+	for _, e := range exps {
+		if e != 0 {
+			res = append(res, power{base, Number{e}})
+		}
+	}
+	if exp != nil && !isNumber(exp, 0) {
+		res = append(res, power{base, exp})
+	}
+	return res
+}
+
+// factorRank порядок множителей: константы, x, функции, остальное
+func factorRank(e Expr) int {
+	switch e.(type) {
+	case Number, Constant:
+		return 0
+	case Variable:
+		return 1
+	case Call:
+		return 2
+	}
+	return 3
+}
+
+// expr записывает произведение: p*числитель/(q*знаменатель)
+func (t product) expr() Expr {
+	num, den := t.split()
+	numE := mulAll(math.Abs(t.p), num.factors)
+	if t.q != 1 || len(den.factors) > 0 {
+		numE = Binary{'/', numE, mulAll(t.q, den.factors)}
+	}
+	if t.p >= 0 {
+		return numE
+	}
+	switch n := numE.(type) {
+	case Number:
+		return Number{-n.Value}
+	case Binary:
+		if c, ok := n.L.(Number); ok && n.Op == '*' {
+			return Binary{'*', Number{-c.Value}, n.R}
+		}
+		if b, ok := n.L.(Binary); ok && n.Op == '/' && b.Op == '*' {
+			if c, ok := b.L.(Number); ok {
+				return Binary{'/', Binary{'*', Number{-c.Value}, b.R}, n.R}
+			}
+		}
+		if c, ok := n.L.(Number); ok && n.Op == '/' {
+			return Binary{'/', Number{-c.Value}, n.R}
+		}
+	}
+	return Unary{numE}
+}
+
+// mulAll произведение числа c и степеней
+func mulAll(c float64, factors []power) Expr {
+	var res Expr
+	for _, f := range factors {
+		var e Expr = f.base
+		if !isNumber(f.exp, 1) {
+			e = Binary{'^', f.base, f.exp}
+		}
+		if res == nil {
+			res = e
+		} else {
+			res = Binary{'*', res, e}
+		}
+	}
+	switch {
+	case res == nil:
+		return Number{c}
+	case c != 1:
+		return Binary{'*', Number{c}, res}
+	}
+	return res
+}
+
+// reduce сокращает дробь p/q; нецелые коэффициенты делятся сразу
+func reduce(p, q float64) (float64, float64) {
+	if q < 0 {
+		p, q = -p, -q
+	}
+	if !isInteger(p) || !isInteger(q) {
+		return p / q, 1
+	}
+	if g := gcd(p, q); g > 1 {
+		p, q = p/g, q/g
+	}
+	return p, q
+}
+
+// simplifyPower упрощает степень; целая степень произведения раскрывается,
+// если все показатели множителей целые: (2*x)^2 = 4*x^2, но (x^0.5)^2 остается
+func simplifyPower(l, r Expr) Expr {
+	switch {
+	case isNumber(r, 0), isNumber(l, 1):
+		return Number{1}
+	case isNumber(r, 1):
+		return l
+	}
+	rn, rok := r.(Number)
+	if ln, ok := l.(Number); ok && rok {
+		if v, ok := fold('^', ln.Value, rn.Value); ok {
+			return Number{v}
+		}
+	}
+	if !rok || !isInteger(rn.Value) {
+		return Binary{'^', l, r}
+	}
+	t := toProduct(l)
+	for i, f := range t.factors {
+		c, ok := f.exp.(Number)
+		if !ok || !isInteger(c.Value) {
+			return Binary{'^', l, r}
+		}
+		t.factors[i].exp = Number{c.Value * rn.Value}
+	}
+	if rn.Value < 0 {
+		if t.p == 0 {
+			return Binary{'^', l, r}
+		}
+		// отрицательная степень переворачивает коэффициент: (2*x)^-1 = 1/(2*x)
+		t.p, t.q = math.Pow(t.q, -rn.Value), math.Pow(t.p, -rn.Value)
+	} else {
+		t.p, t.q = math.Pow(t.p, rn.Value), math.Pow(t.q, rn.Value)
+	}
+	return t.normalize().expr()
+}
+
+func simplifyCall(name string, args []Expr) Expr {
+	if name == "pow" {
+		return simplifyPower(args[0], args[1])
+	}
+	if c, ok := args[0].(Constant); ok && name == "ln" && c.Name == "e" {
+		return Number{1}
+	}
+	// функцию от числа вычисляем, только если получается целое: sin(0), ln(1), sqrt(4)
+	if n, ok := args[0].(Number); ok {
+		if v := unaryFunctions[name](n.Value); isInteger(v) {
+			return Number{v}
+		}
+	}
+	return Call{name, args}
+}
+
+// fold вычисляет операцию над числами; деление и степень только с целым результатом
+func fold(op byte, a, b float64) (float64, bool) {
+	v := Binary{op, Number{a}, Number{b}}.Eval(0)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, false
+	}
+	if (op == '/' || op == '^') && !isInteger(v) {
+		return 0, false
+	}
+	return v, true
+}
+
+// nonZero проверяет, что выражение не обращается в ноль ни при каком x
+func nonZero(e Expr) bool {
+	switch n := e.(type) {
+	case Number:
+		return n.Value != 0
+	case Constant:
+		return true
+	case Unary:
+		return nonZero(n.X)
+	case Call:
+		return n.Name == "exp"
+	case Binary:
+		switch n.Op {
+		case '*', '/':
+			return nonZero(n.L) && nonZero(n.R)
+		case '^':
+			return nonZero(n.L)
+		}
+	}
+	return false
+}
+
+// definedEverywhere проверяет, что выражение определено при всех x
+func definedEverywhere(e Expr) bool {
+	switch n := e.(type) {
+	case Number, Constant, Variable:
+		return true
+	case Unary:
+		return definedEverywhere(n.X)
+	case Call:
+		switch n.Name {
+		case "pow":
+			return definedPower(n.Args[0], n.Args[1])
+		case "sin", "cos", "exp", "abs":
+			return definedEverywhere(n.Args[0])
+		}
+		return false
+	case Binary:
+		switch n.Op {
+		case '+', '-', '*':
+			return definedEverywhere(n.L) && definedEverywhere(n.R)
+		case '/':
+			return definedEverywhere(n.L) && definedEverywhere(n.R) && nonZero(n.R)
+		default:
+			return definedPower(n.L, n.R)
+		}
+	}
+	return false
+}
+
+// definedPower проверяет, что степень base^exp определена при всех x:
+// показатель целый, а при отрицательном показателе основание не обращается в ноль
+func definedPower(base, exp Expr) bool {
+	c, ok := exp.(Number)
+	if !ok || !isInteger(c.Value) || !definedEverywhere(base) {
+		return false
+	}
+	return c.Value >= 0 || nonZero(base)
+}
+
+func isNumber(e Expr, v float64) bool {
+	n, ok := e.(Number)
+	return ok && n.Value == v
+}
+
+func isInteger(v float64) bool {
+	return v == math.Trunc(v) && !math.IsInf(v, 0)
+}
+
+func gcd(a, b float64) float64 {
+	a, b = math.Abs(a), math.Abs(b)
+	for b != 0 {
+		a, b = b, math.Mod(a, b)
+	}
+	return a
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivativeMatchesCentralDifference(t *testing.T) {
+	tests := []struct {
+		in     string
+		points []float64
+	}{
+		{"x^3 - 2*x + 1", []float64{-1.5, 0, 0.7, 2}},
+		{"-(x + 1)*3", []float64{-1, 2}},
+		{"3/x - 5/x", []float64{0.5, 2}},
+		{"x/(x + 1)", []float64{0.2, 3}},
+		{"x^3/x", []float64{-2, 0.5, 1.5}},
+		{"(x^2)^0.5", []float64{-1.5, 0.8}},
+		{"2^-x", []float64{-1, 0.5, 3}},
+		{"x^x", []float64{0.5, 1.3}},
+		{"sqrt(x^2 + 1)", []float64{-2, 0, 1}},
+		{"sin(x)^2 + cos(x)^2", []float64{0.3, 2}},
+		{"exp(-x) * ln(x + e)", []float64{0, 1.1}},
+		{"pow(x, 0.5) * sin(pi*x)", []float64{0.4, 1.7}},
+		{"abs(x - 1)", []float64{0.3, 2.5}},
+		{"x - sin(x)", []float64{0.5, 1}},
+		{"(2*x)^-1", []float64{-1.5, 0.5, 2}},
+		{"pow(3*x, -2)", []float64{-1, 0.7, 2}},
+		{"2^-1*x", []float64{-1, 3}},
+		{"(-2*x)^-3 + 3^-1", []float64{-0.8, 1.2}},
+	}
+	const h = 1e-5
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := ParseExpression(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.in, err)
+			}
+			f, d := Compile(e), Compile(Derivative(e))
+			for _, x := range tt.points {
+				want := (f(x+h) - f(x-h)) / (2 * h)
+				got := d(x)
+				if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+					t.Errorf("d/dx %q at x = %v: %v (%v), central difference %v", tt.in, x, got, Derivative(e), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplifyKeepsValue(t *testing.T) {
+	tests := []struct {
+		in     string
+		points []float64
+	}{
+		{"x*x*x - x^2*x", []float64{-1, 2}},
+		{"2*x + 3 - x - 1", []float64{0, 4}},
+		{"x/2/3 + x/6", []float64{1, -3}},
+		{"(x + 1)*(x + 1)/(x + 1)", []float64{1, 2}},
+		{"ln(exp(x))*0 + 1*x^1", []float64{0.5, 2}},
+		{"3^-1", []float64{0}},
+		{"(2*x)^-1", []float64{-1.5, 0.5, 2}},
+		{"pow(3*x, -2)", []float64{-1, 0.7, 2}},
+		{"2^-1*x", []float64{-1, 3}},
+		{"(x/3)^-2 * x", []float64{-2, 1.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := ParseExpression(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.in, err)
+			}
+			s := Simplify(e)
+			for _, x := range tt.points {
+				want, got := e.Eval(x), s.Eval(x)
+				if math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+					t.Errorf("%q -> %q at x = %v: %v, want %v", tt.in, s, x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplifyKeepsDomain(t *testing.T) {
+	tests := []struct {
+		in string
+		x  float64
+	}{
+		{"x^0.5 - x^0.5", -1},
+		{"sqrt(x) - sqrt(x)", -4},
+		{"ln(x) - ln(x)", -1},
+		{"1/x - 1/x", 0},
+		{"x/(x + 1) + 1/(x + 1) - (x + 1)/(x + 1)", -1},
+		{"x^3/x", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := ParseExpression(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.in, err)
+			}
+			s := Simplify(e)
+			want, got := e.Eval(tt.x), s.Eval(tt.x)
+			if math.IsNaN(want) != math.IsNaN(got) || math.IsInf(want, 0) != math.IsInf(got, 0) {
+				t.Errorf("%q -> %q at x = %v: %v, want %v", tt.in, s, tt.x, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import "math"
+
+// Автоматическое дифференцирование прямого хода. Функция записывается один раз
+// через методы autodiff и вычисляется на числах нужного типа: Dual дает первую
+// производную по одному направлению, HyperDual - еще и вторую производную
+
+// autodiff операции, которые должен поддерживать тип чисел
+type autodiff[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(T) T
+	Div(T) T
+	Neg() T
+	// Shift прибавление константы
+	Shift(c float64) T
+	// Scale умножение на константу
+	Scale(c float64) T
+	// Lift константа того же типа
+	Lift(c float64) T
+	Sin() T
+	Cos() T
+	Exp() T
+	Ln() T
+	Sqrt() T
+	Pow(k float64) T
+	Abs() T
+}
+
+// Dual дуальное число Re + Eps*ε, где ε^2 = 0
+type Dual struct {
+	Re, Eps float64
+}
+
+// chain применение функции со значением f0 и производной f1
+func (a Dual) chain(f0, f1 float64) Dual {
+	return Dual{f0, f1 * a.Eps}
+}
+
+func (a Dual) Add(b Dual) Dual      { return Dual{a.Re + b.Re, a.Eps + b.Eps} }
+func (a Dual) Sub(b Dual) Dual      { return Dual{a.Re - b.Re, a.Eps - b.Eps} }
+func (a Dual) Mul(b Dual) Dual      { return Dual{a.Re * b.Re, a.Re*b.Eps + a.Eps*b.Re} }
+func (a Dual) Div(b Dual) Dual      { return a.Mul(b.chain(1/b.Re, -1/(b.Re*b.Re))) }
+func (a Dual) Neg() Dual            { return Dual{-a.Re, -a.Eps} }
+func (a Dual) Shift(c float64) Dual { return Dual{a.Re + c, a.Eps} }
+func (a Dual) Scale(c float64) Dual { return Dual{a.Re * c, a.Eps * c} }
+func (Dual) Lift(c float64) Dual    { return Dual{c, 0} }
+func (a Dual) Sin() Dual            { return a.chain(math.Sin(a.Re), math.Cos(a.Re)) }
+func (a Dual) Cos() Dual            { return a.chain(math.Cos(a.Re), -math.Sin(a.Re)) }
+func (a Dual) Exp() Dual            { e := math.Exp(a.Re); return a.chain(e, e) }
+func (a Dual) Ln() Dual             { return a.chain(math.Log(a.Re), 1/a.Re) }
+func (a Dual) Sqrt() Dual           { s := math.Sqrt(a.Re); return a.chain(s, 0.5/s) }
+func (a Dual) Pow(k float64) Dual   { return a.chain(math.Pow(a.Re, k), k*math.Pow(a.Re, k-1)) }
+func (a Dual) Abs() Dual            { return a.chain(math.Abs(a.Re), sign(a.Re)) }
+
+// HyperDual гипердуальное число Re + E1*ε1 + E2*ε2 + E12*ε1ε2, где ε1^2 = ε2^2 = 0.
+// При E1 = E2 = 1 коэффициент E12 - вторая производная
+type HyperDual struct {
+	Re, E1, E2, E12 float64
+}
+
+// chain применение функции со значением f0, первой производной f1 и второй f2
+func (a HyperDual) chain(f0, f1, f2 float64) HyperDual {
+	return HyperDual{f0, f1 * a.E1, f1 * a.E2, f1*a.E12 + f2*a.E1*a.E2}
+}
+
+func (a HyperDual) Add(b HyperDual) HyperDual {
+	return HyperDual{a.Re + b.Re, a.E1 + b.E1, a.E2 + b.E2, a.E12 + b.E12}
+}
+
+func (a HyperDual) Sub(b HyperDual) HyperDual { return a.Add(b.Neg()) }
+
+func (a HyperDual) Scale(c float64) HyperDual {
+	return HyperDual{a.Re * c, a.E1 * c, a.E2 * c, a.E12 * c}
+}
+
+func (a HyperDual) Mul(b HyperDual) HyperDual {
+	return HyperDual{a.Re * b.Re, a.Re*b.E1 + a.E1*b.Re, a.Re*b.E2 + a.E2*b.Re,
+		a.Re*b.E12 + a.E1*b.E2 + a.E2*b.E1 + a.E12*b.Re}
+}
+
+func (a HyperDual) Div(b HyperDual) HyperDual {
+	return a.Mul(b.chain(1/b.Re, -1/(b.Re*b.Re), 2/(b.Re*b.Re*b.Re)))
+}
+
+func (a HyperDual) Neg() HyperDual            { return a.Scale(-1) }
+func (a HyperDual) Shift(c float64) HyperDual { return HyperDual{a.Re + c, a.E1, a.E2, a.E12} }
+func (HyperDual) Lift(c float64) HyperDual    { return HyperDual{Re: c} }
+func (a HyperDual) Sin() HyperDual            { s, c := math.Sincos(a.Re); return a.chain(s, c, -s) }
+func (a HyperDual) Cos() HyperDual            { s, c := math.Sincos(a.Re); return a.chain(c, -s, -c) }
+func (a HyperDual) Exp() HyperDual            { e := math.Exp(a.Re); return a.chain(e, e, e) }
+func (a HyperDual) Ln() HyperDual             { return a.chain(math.Log(a.Re), 1/a.Re, -1/(a.Re*a.Re)) }
+func (a HyperDual) Abs() HyperDual            { return a.chain(math.Abs(a.Re), sign(a.Re), 0) }
+
+func (a HyperDual) Sqrt() HyperDual {
+	s := math.Sqrt(a.Re)
+	return a.chain(s, 0.5/s, -0.25/(s*a.Re))
+}
+
+func (a HyperDual) Pow(k float64) HyperDual {
+	return a.chain(math.Pow(a.Re, k), k*math.Pow(a.Re, k-1), k*(k-1)*math.Pow(a.Re, k-2))
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
+
+// systemFunction система двух функций от x и y, записанная для любого типа чисел
+type systemFunction[T autodiff[T]] func(x, y T) []T
+
+// partialDerivative частная производная i-й функции системы по x (byX) или по y
+func partialDerivative(system systemFunction[Dual], i int, byX bool) function {
+	return func(x, y float64) float64 {
+		dx, dy := Dual{x, 0}, Dual{y, 1}
+		if byX {
+			dx, dy = Dual{x, 1}, Dual{y, 0}
+		}
+		return system(dx, dy)[i].Eps
+	}
+}
+
+// jacobianAt значения функций системы и матрица Якоби в точке (x, y):
+// один проход по направлению x и один по y
+func jacobianAt(system systemFunction[Dual], x, y float64) ([]float64, [][]float64) {
+	byX := system(Dual{x, 1}, Dual{y, 0})
+	byY := system(Dual{x, 0}, Dual{y, 1})
+	values := make([]float64, len(byX))
+	jacobian := make([][]float64, len(byX))
+	for i := range byX {
+		values[i] = byX[i].Re
+		jacobian[i] = []float64{byX[i].Eps, byY[i].Eps}
+	}
+	return values, jacobian
+}
+
+// evalAutodiff вычисляет выражение на числах типа T: так из разобранной
+// функции получаются производные без символьного дифференцирования
+func evalAutodiff[T autodiff[T]](e Expr, x T) T {
+	if !dependsOnX(e) {
+		return x.Lift(e.Eval(0))
+	}
+	switch n := e.(type) {
+	case Unary:
+		return evalAutodiff(n.X, x).Neg()
+	case Binary:
+		if n.Op == '^' {
+			return powAutodiff(n.L, n.R, x)
+		}
+		l, r := evalAutodiff(n.L, x), evalAutodiff(n.R, x)
+		switch n.Op {
+		case '+':
+			return l.Add(r)
+		case '-':
+			return l.Sub(r)
+		case '*':
+			return l.Mul(r)
+		default:
+			return l.Div(r)
+		}
+	case Call:
+		if n.Name == "pow" {
+			return powAutodiff(n.Args[0], n.Args[1], x)
+		}
+		arg := evalAutodiff(n.Args[0], x)
+		switch n.Name {
+		case "sin":
+			return arg.Sin()
+		case "cos":
+			return arg.Cos()
+		case "exp":
+			return arg.Exp()
+		case "ln":
+			return arg.Ln()
+		case "sqrt":
+			return arg.Sqrt()
+		default:
+			return arg.Abs()
+		}
+	}
+	// переменная x
+	return x
+}
+
+// powAutodiff степень base^exp: при постоянном показателе через Pow,
+// иначе как exp(exp*ln(base))
+func powAutodiff[T autodiff[T]](base, exp Expr, x T) T {
+	b := evalAutodiff(base, x)
+	if dependsOnX(exp) {
+		return evalAutodiff(exp, x).Mul(b.Ln()).Exp()
+	}
+	switch k := exp.Eval(0); k {
+	case 0:
+		return x.Lift(1)
+	case 1:
+		return b
+	default:
+		return b.Pow(k)
+	}
+}
+
+// secondDerivativeAt вторая производная выражения на гипердуальных числах
+func secondDerivativeAt(e Expr) func(x float64) float64 {
+	return func(x float64) float64 {
+		return evalAutodiff(e, HyperDual{x, 1, 1, 0}).E12
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSecondDerivativeMatchesSymbolic(t *testing.T) {
+	tests := []struct {
+		in     string
+		points []float64
+	}{
+		{"x^3 - 2*x + 1", []float64{-1.5, 0, 2}},
+		{"x^4/12 - x", []float64{-1, 0.5}},
+		{"(2*x)^-1", []float64{-1.5, 0.5, 2}},
+		{"x/(x + 1)", []float64{0.2, 3}},
+		{"sqrt(x^2 + 1)", []float64{-2, 0, 1}},
+		{"sin(x)^2 + cos(x)", []float64{0.3, 2}},
+		{"exp(-x) * ln(x + e)", []float64{0, 1.1}},
+		{"2^x", []float64{-1, 0.5}},
+		{"x^x", []float64{0.5, 1.3}},
+		{"pow(x, 0.5) * sin(pi*x)", []float64{0.4, 1.7}},
+		{"abs(x - 1)*x", []float64{0.3, 2.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := ParseExpression(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.in, err)
+			}
+			want, got := Compile(Derivative(Derivative(e))), secondDerivativeAt(e)
+			first := Compile(Derivative(e))
+			for _, x := range tt.points {
+				if w, g := want(x), got(x); math.Abs(g-w) > 1e-9*math.Max(1, math.Abs(w)) {
+					t.Errorf("f''(%v) for %q: %v, symbolic %v", x, tt.in, g, w)
+				}
+				if w, g := first(x), evalAutodiff(e, Dual{x, 1}).Eps; math.Abs(g-w) > 1e-9*math.Max(1, math.Abs(w)) {
+					t.Errorf("f'(%v) for %q: %v, symbolic %v", x, tt.in, g, w)
+				}
+			}
+		})
+	}
+}
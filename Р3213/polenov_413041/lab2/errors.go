@@ -35,6 +35,13 @@ func (se SystemError) Error() string {
 	return "Нарушено условие использования метода простых итераций для решения системы нелинейных уравнений"
 }
 
+type JacobianError struct {
+}
+
+func (je JacobianError) Error() string {
+	return "Матрица Якоби вырождена, метод Ньютона неприменим"
+}
+
 type ParseError struct {
 	value string
 }
@@ -65,6 +72,23 @@ func (sie SimpleIterationError) Error() string {
 	return sie.text
 }
 
+type ExpressionError struct {
+	pos  int
+	text string
+}
+
+func (ee ExpressionError) Error() string {
+	return "Ошибка в выражении функции, позиция " + strconv.Itoa(ee.pos) + ": " + ee.text
+}
+
+type ZeroDivisionError struct {
+	method string
+}
+
+func (zde ZeroDivisionError) Error() string {
+	return "Деление на ноль в методе " + zde.method
+}
+
 type IterationError struct{}
 
 func (ie IterationError) Error() string {
@@ -0,0 +1,485 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr узел дерева разбора выражения от одной переменной x
+type Expr interface {
+	// Eval вычисляет значение выражения в точке x
+	Eval(x float64) float64
+	// String запись выражения с минимально необходимыми скобками
+	String() string
+}
+
+// Number числовая константа
+type Number struct {
+	Value float64
+}
+
+// Constant именованная константа (pi, e)
+type Constant struct {
+	Name  string
+	Value float64
+}
+
+// Variable переменная x
+type Variable struct{}
+
+// Unary унарный минус
+type Unary struct {
+	X Expr
+}
+
+// Binary бинарная операция: +, -, *, / или ^
+type Binary struct {
+	Op   byte
+	L, R Expr
+}
+
+// Call вызов элементарной функции
+type Call struct {
+	Name string
+	Args []Expr
+}
+
+// Элементарные функции одного аргумента
+var unaryFunctions = map[string]func(float64) float64{
+	"sin":  math.Sin,
+	"cos":  math.Cos,
+	"exp":  math.Exp,
+	"ln":   math.Log,
+	"sqrt": math.Sqrt,
+	"abs":  math.Abs,
+}
+
+// Именованные константы
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+func (n Number) Eval(float64) float64   { return n.Value }
+func (c Constant) Eval(float64) float64 { return c.Value }
+func (Variable) Eval(x float64) float64 { return x }
+func (u Unary) Eval(x float64) float64  { return -u.X.Eval(x) }
+
+func (b Binary) Eval(x float64) float64 {
+	l, r := b.L.Eval(x), b.R.Eval(x)
+	switch b.Op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	case '/':
+		return l / r
+	default:
+		return math.Pow(l, r)
+	}
+}
+
+func (c Call) Eval(x float64) float64 {
+	if c.Name == "pow" {
+		return math.Pow(c.Args[0].Eval(x), c.Args[1].Eval(x))
+	}
+	return unaryFunctions[c.Name](c.Args[0].Eval(x))
+}
+
+// Приоритеты операций для расстановки скобок при выводе
+const (
+	precSum = iota + 1
+	precProduct
+	precUnary
+	precPower
+	precAtom
+)
+
+func precedence(e Expr) int {
+	switch n := e.(type) {
+	case Binary:
+		switch n.Op {
+		case '+', '-':
+			return precSum
+		case '*', '/':
+			return precProduct
+		default:
+			return precPower
+		}
+	case Unary:
+		return precUnary
+	case Number:
+		if n.Value < 0 {
+			return precUnary
+		}
+	}
+	return precAtom
+}
+
+// wrap берет выражение в скобки, если его приоритет ниже требуемого
+func wrap(e Expr, prec int) string {
+	if precedence(e) < prec {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+func (n Number) String() string   { return strconv.FormatFloat(n.Value, 'g', -1, 64) }
+func (c Constant) String() string { return c.Name }
+func (Variable) String() string   { return "x" }
+func (u Unary) String() string    { return "-" + wrap(u.X, precProduct) }
+
+func (b Binary) String() string {
+	p := precedence(b)
+	switch b.Op {
+	case '^':
+		// возведение в степень правоассоциативно
+		return wrap(b.L, p+1) + "^" + wrap(b.R, p)
+	case '+':
+		return wrap(b.L, p) + " + " + wrap(b.R, p)
+	case '*':
+		return wrap(b.L, p) + "*" + wrap(b.R, p)
+	case '/':
+		// деление не ассоциативно, правый операнд того же приоритета в скобках
+		return wrap(b.L, p) + "/" + wrap(b.R, p+1)
+	default:
+		return wrap(b.L, p) + " - " + wrap(b.R, p+1)
+	}
+}
+
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = a.String()
+	}
+	return c.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Compile превращает дерево выражения в замыкание. Поддеревья без x
+// вычисляются один раз, целые степени считаются быстрым возведением в степень
+func Compile(e Expr) func(x float64) float64 {
+	if !dependsOnX(e) {
+		v := e.Eval(0)
+		return func(float64) float64 { return v }
+	}
+	switch n := e.(type) {
+	case Variable:
+		return func(x float64) float64 { return x }
+	case Unary:
+		f := Compile(n.X)
+		return func(x float64) float64 { return -f(x) }
+	case Binary:
+		l := Compile(n.L)
+		if n.Op == '^' && !dependsOnX(n.R) {
+			if k := n.R.Eval(0); k == math.Trunc(k) && math.Abs(k) <= 64 {
+				if k < 0 {
+					return func(x float64) float64 { return 1 / FastPow(l(x), int(-k)) }
+				}
+				return func(x float64) float64 { return FastPow(l(x), int(k)) }
+			}
+		}
+		r := Compile(n.R)
+		switch n.Op {
+		case '+':
+			return func(x float64) float64 { return l(x) + r(x) }
+		case '-':
+			return func(x float64) float64 { return l(x) - r(x) }
+		case '*':
+			return func(x float64) float64 { return l(x) * r(x) }
+		case '/':
+			return func(x float64) float64 { return l(x) / r(x) }
+		default:
+			return func(x float64) float64 { return math.Pow(l(x), r(x)) }
+		}
+	case Call:
+		if n.Name == "pow" {
+			return Compile(Binary{'^', n.Args[0], n.Args[1]})
+		}
+		f, arg := unaryFunctions[n.Name], Compile(n.Args[0])
+		return func(x float64) float64 { return f(arg(x)) }
+	}
+	return e.Eval
+}
+
+// dependsOnX проверяет, входит ли переменная x в выражение
+func dependsOnX(e Expr) bool {
+	switch n := e.(type) {
+	case Variable:
+		return true
+	case Unary:
+		return dependsOnX(n.X)
+	case Binary:
+		return dependsOnX(n.L) || dependsOnX(n.R)
+	case Call:
+		for _, a := range n.Args {
+			if dependsOnX(a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolynomialExpr строит многочлен по коэффициентам в порядке возрастания степеней
+func PolynomialExpr(koeff []float64) Expr {
+	var e Expr
+	for i := len(koeff) - 1; i >= 0; i-- {
+		c := koeff[i]
+		if c == 0 && (e != nil || i > 0) {
+			continue
+		}
+		negative := c < 0 && e != nil
+		if negative {
+			c = -c
+		}
+		var term Expr = Number{c}
+		if i > 0 {
+			var power Expr = Variable{}
+			if i > 1 {
+				power = Binary{'^', Variable{}, Number{float64(i)}}
+			}
+			switch c {
+			case 1:
+				term = power
+			case -1:
+				term = Unary{power}
+			default:
+				term = Binary{'*', term, power}
+			}
+		}
+		switch {
+		case e == nil:
+			e = term
+		case negative:
+			e = Binary{'-', e, term}
+		default:
+			e = Binary{'+', e, term}
+		}
+	}
+	if e == nil {
+		return Number{0}
+	}
+	return e
+}
+
+// Разбор выражения рекурсивным спуском по грамматике
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | "+" unary | power
+//	power   = atom [ ("^" | "**") unary ]
+//	atom    = number | "x" | constant | function "(" sum { "," sum } ")" | "(" sum ")"
+
+// token лексема выражения
+type token struct {
+	kind  byte // 'n' - число, 'i' - имя, иначе символ операции, 0 - конец строки
+	text  string
+	value float64
+	pos   int
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+// ParseExpression разбирает выражение от x
+func ParseExpression(s string) (Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != 0 {
+		return nil, ExpressionError{t.pos, "лишний символ " + strconv.Quote(t.text)}
+	}
+	return e, nil
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// экспонента, только если за ней действительно идут цифры: 1e-3, но не 2*e
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					i = j
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						i++
+					}
+				}
+			}
+			text := string(runes[start:i])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, ExpressionError{start + 1, "некорректное число " + strconv.Quote(text)}
+			}
+			tokens = append(tokens, token{kind: 'n', text: text, value: v, pos: start + 1})
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: 'i', text: strings.ToLower(string(runes[start:i])), pos: start + 1})
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: '^', text: "**", pos: i + 1})
+			i += 2
+		case strings.ContainsRune("+-*/^(),", r):
+			tokens = append(tokens, token{kind: byte(r), text: string(r), pos: i + 1})
+			i++
+		default:
+			return nil, ExpressionError{i + 1, "неизвестный символ " + strconv.QuoteRune(r)}
+		}
+	}
+	return append(tokens, token{pos: len(runes) + 1, text: "конец строки"}), nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != 0 {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) expect(kind byte) error {
+	if t := p.next(); t.kind != kind {
+		return ExpressionError{t.pos, "ожидался символ " + strconv.Quote(string(kind)) + ", найдено " + strconv.Quote(t.text)}
+	}
+	return nil
+}
+
+func (p *parser) sum() (Expr, error) {
+	e, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().kind; k == '+' || k == '-'; k = p.peek().kind {
+		p.next()
+		r, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		e = Binary{k, e, r}
+	}
+	return e, nil
+}
+
+func (p *parser) product() (Expr, error) {
+	e, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().kind; k == '*' || k == '/'; k = p.peek().kind {
+		p.next()
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		e = Binary{k, e, r}
+	}
+	return e, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	switch p.peek().kind {
+	case '-':
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Unary{x}, nil
+	case '+':
+		p.next()
+		return p.unary()
+	}
+	return p.power()
+}
+
+func (p *parser) power() (Expr, error) {
+	base, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != '^' {
+		return base, nil
+	}
+	p.next()
+	// показатель разбирается через unary, поэтому 2^-x и 2^3^2 = 2^(3^2)
+	exp, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return Binary{'^', base, exp}, nil
+}
+
+func (p *parser) atom() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case 'n':
+		return Number{t.value}, nil
+	case '(':
+		e, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(')')
+	case 'i':
+		if t.text == "x" {
+			return Variable{}, nil
+		}
+		if v, ok := constants[t.text]; ok && p.peek().kind != '(' {
+			return Constant{t.text, v}, nil
+		}
+		arity := 1
+		if t.text == "pow" {
+			arity = 2
+		} else if _, ok := unaryFunctions[t.text]; !ok {
+			return nil, ExpressionError{t.pos, "неизвестное имя " + strconv.Quote(t.text)}
+		}
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		call := Call{Name: t.text}
+		for {
+			arg, err := p.sum()
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+			if p.peek().kind != ',' {
+				break
+			}
+			p.next()
+		}
+		if len(call.Args) != arity {
+			return nil, ExpressionError{t.pos, "функция " + t.text + " принимает аргументов: " + strconv.Itoa(arity)}
+		}
+		return call, p.expect(')')
+	}
+	return nil, ExpressionError{t.pos, "ожидалось число, x, функция или скобка, найдено " + strconv.Quote(t.text)}
+}
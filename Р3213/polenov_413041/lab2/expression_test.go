@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseExpressionRoundTrip(t *testing.T) {
+	tests := []string{
+		"x^3 - 2*x + 1",
+		"-x^2",
+		"(-x)^2",
+		"2^-x",
+		"2^3^2",
+		"(2^3)^2",
+		"x - (x - 1)",
+		"x / (2 * x)",
+		"x/2/3",
+		"-(x + 1)*3",
+		"sin(x)^2 + cos(x)^2",
+		"pow(x, 0.5) + sqrt(x)",
+		"exp(-x) * ln(x + e)",
+		"abs(x - pi)",
+		"1e-3*x + 2.5E2",
+		"x**2",
+	}
+	points := []float64{0.3, 1.7, 2.9}
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			e, err := ParseExpression(s)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", s, err)
+			}
+			printed := e.String()
+			again, err := ParseExpression(printed)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) of printed form: %v", printed, err)
+			}
+			if again.String() != printed {
+				t.Errorf("String() is not stable: %q -> %q", printed, again.String())
+			}
+			for _, x := range points {
+				want, got := e.Eval(x), again.Eval(x)
+				if math.Abs(want-got) > 1e-12*math.Max(1, math.Abs(want)) {
+					t.Errorf("x = %v: %q gives %v, %q gives %v", x, s, want, printed, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+	}{
+		{"", 1},
+		{"x +", 4},
+		{"x $ 2", 3},
+		{"2 x", 3},
+		{"(x + 1", 7},
+		{"x + 1)", 6},
+		{"foo(x)", 1},
+		{"sin x", 5},
+		{"pow(x)", 1},
+		{"1..2 + x", 1},
+		{"x * * 2", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParseExpression(tt.in)
+			var ee ExpressionError
+			if !errors.As(err, &ee) {
+				t.Fatalf("ParseExpression(%q) = %v, want ExpressionError", tt.in, err)
+			}
+			if ee.pos != tt.pos {
+				t.Errorf("ParseExpression(%q) error at %d, want %d: %v", tt.in, ee.pos, tt.pos, err)
+			}
+		})
+	}
+}
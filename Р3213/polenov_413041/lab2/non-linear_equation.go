@@ -11,12 +11,15 @@ import (
 
 // Определение структур уравнения и ошибок
 type equation struct {
-	koeff       []float64
-	hasSolution bool
-	a           float64
-	b           float64
-	accuracy    float64
-	itera       int
+	expr             Expr
+	derivative       Expr
+	secondDerivative Expr
+	f                func(x float64) float64
+	hasSolution      bool
+	a                float64
+	b                float64
+	accuracy         float64
+	itera            int
 }
 
 func checkMultipleError(eq equation) bool {
@@ -31,26 +34,27 @@ func checkMultipleError(eq equation) bool {
 	return f(eq.a)*f(eq.b) < 0
 }
 
-// Функция ввода коеффициентов уравнения
-func getKoeff(in *bufio.Reader) ([]float64, error) {
-	fmt.Print("Введите коэффициенты уравнения в порядке возрастания степеней: ")
-	rowKoeff, prefix, err := in.ReadLine()
-	if err != nil {
-		return nil, ReadError{"Невозможно прочитать коэффициенты"}
+// Функция ввода уравнения: выражение от x или коэффициенты многочлена
+func getFunction(in *bufio.Reader) (Expr, error) {
+	fmt.Print("Введите функцию f(x) (например, x^3 - 2*sin(x) + e^(-x))\nили коэффициенты многочлена в порядке возрастания степеней: ")
+	row, err := in.ReadString('\n')
+	if err != nil && row == "" {
+		return nil, ReadError{"Невозможно прочитать функцию"}
 	}
-	if prefix {
-		return nil, nil
+	fields := strings.Fields(row)
+	if len(fields) == 0 {
+		return nil, ReadError{"Функция не введена"}
 	}
-	stringKoeff := strings.Split(string(rowKoeff), " ")
-	answer := make([]float64, len(stringKoeff))
-	for index, number := range stringKoeff {
+	koeff := make([]float64, len(fields))
+	for index, number := range fields {
 		value, err := strconv.ParseFloat(number, 64)
 		if err != nil {
-			return nil, ParseError{number}
+			// не список чисел, разбираем как выражение
+			return ParseExpression(row)
 		}
-		answer[index] = value
+		koeff[index] = value
 	}
-	return answer, nil
+	return PolynomialExpr(koeff), nil
 }
 
 // Функция чтения границ изоляции корня
@@ -70,51 +74,20 @@ func getAccuracy(in *bufio.Reader) float64 {
 	return epsilon
 }
 
-// Получение второй производной исходной функции
+// Получение второй производной исходной функции: точное значение на
+// гипердуальных числах, без дифференцирования производной
 func getSecondDerivative(eq equation) func(x float64) float64 {
-	if len(eq.koeff) < 3 {
-		return nil
-	}
-	newKoeff := make([]float64, len(eq.koeff)-2)
-	for i := 2; i < len(eq.koeff); i++ {
-		newKoeff[i-2] = float64((i-1)*i) * eq.koeff[i]
-	}
-	return func(x float64) float64 {
-		var answer float64 = 0
-		for index, koeff := range newKoeff {
-			answer += koeff * FastPow(x, index)
-		}
-		return answer
-	}
+	return secondDerivativeAt(eq.expr)
 }
 
 // Получение первой производной исходной функции
 func getFirstDerivative(eq equation) func(x float64) float64 {
-	if len(eq.koeff) < 2 {
-		return nil
-	}
-	newKoeff := make([]float64, len(eq.koeff)-1)
-	for i := 1; i < len(eq.koeff); i++ {
-		newKoeff[i-1] = float64(i) * eq.koeff[i]
-	}
-	return func(x float64) float64 {
-		var answer float64 = 0
-		for index, koeff := range newKoeff {
-			answer += koeff * FastPow(x, index)
-		}
-		return answer
-	}
+	return Compile(eq.derivative)
 }
 
 // Получение исходной функции
 func getSourceFunction(eq equation) func(x float64) float64 {
-	return func(x float64) float64 {
-		var answer float64 = 0
-		for index, koeff := range eq.koeff {
-			answer += koeff * FastPow(x, index)
-		}
-		return answer
-	}
+	return eq.f
 }
 
 // Главный метод хорд
@@ -123,9 +96,7 @@ func methodChord(eq equation) (float64, int, error) {
 	f := getSourceFunction(eq)
 	var x float64
 	var k int
-	if secondDerivative == nil {
-		x, k = methodChordDefault(eq)
-	} else if f(eq.a)*secondDerivative(eq.a) > 0 {
+	if f(eq.a)*secondDerivative(eq.a) > 0 {
 		x, k = methodChordFixLeftBorder(eq)
 	} else if f(eq.b)*secondDerivative(eq.b) > 0 {
 		x, k = methodChordFixRightBorder(eq)
@@ -175,6 +146,7 @@ func methodChordFixRightBorder(eq equation) (float64, int) {
 	var k = 0
 	for Abs(f(x)) >= eq.accuracy && k < eq.itera {
 		x = x - (eq.b-x)/(f(eq.b)-f(x))*f(x)
+		k++
 	}
 	return x, k
 }
@@ -183,10 +155,12 @@ func methodChordFixRightBorder(eq equation) (float64, int) {
 func methodNewton(eq equation) (float64, int, error) {
 	f := getSourceFunction(eq)
 	firstDerivative := getFirstDerivative(eq)
+	// условие сходимости: начинаем с границы, где f(x0)*f''(x0) > 0
+	secondDerivative := getSecondDerivative(eq)
 	x0 := eq.a
-
-	//if firstDerivative(x0)*getSecondDerivative(eq)(x0) <= 0 {
-	//}
+	if f(eq.a)*secondDerivative(eq.a) <= 0 && f(eq.b)*secondDerivative(eq.b) > 0 {
+		x0 = eq.b
+	}
 
 	x1 := -1.0
 	eps := eq.accuracy
@@ -211,25 +185,21 @@ func methodNewton(eq equation) (float64, int, error) {
 	return x1, k, nil
 }
 
-// Главный метод простых итераций
+// Главный метод простых итераций: x = ϕ(x), где ϕ(x) = x + λf(x)
 func methodSimpleItaration(eq equation) (float64, int, error) {
 	lambda, err := getLambda(eq)
 	if err != nil {
 		return 0, 0, err
 	}
-	phiEq := getNewFunction(eq, lambda)
-	if len(phiEq.koeff) > 2 {
-		phiEq.koeff[1] += 1
-	} else {
-		phiEq.koeff = append(phiEq.koeff, 1)
-	}
-	phiFirstDerivative := getFirstDerivative(phiEq)
+	f := getSourceFunction(eq)
+	firstDerivative := getFirstDerivative(eq)
+	phi := func(x float64) float64 { return x + lambda*f(x) }
+	phiFirstDerivative := func(x float64) float64 { return 1 + lambda*firstDerivative(x) }
 	if Abs(phiFirstDerivative(eq.a)) >= 1 || Abs(phiFirstDerivative(eq.b)) >= 1 {
 		return 0, 0, SimpleIterationError{"Невозможно использовать метод простых итераций: Значения ϕ' < 1"}
 	}
 	var x1 = (eq.a + eq.b) / 2
 	var x2 = x1 + eq.accuracy + 1
-	phi := getSourceFunction(phiEq)
 	var k = 0
 	for Abs(x2-x1) >= eq.accuracy && k < eq.itera {
 		x2 = x1
@@ -245,9 +215,6 @@ func methodSimpleItaration(eq equation) (float64, int, error) {
 // Получение коэффициента лямбда
 func getLambda(eq equation) (float64, error) {
 	firstDerivative := getFirstDerivative(eq)
-	if firstDerivative == nil {
-		return 0, SimpleIterationError{"Невозможно использовать метод простых итераций: Первая производная равна 0"}
-	}
 	i := eq.a
 	var maximum float64 = -1
 	var isPositive = firstDerivative(i) > 0 || firstDerivative(i+eq.accuracy) > 0
@@ -258,22 +225,15 @@ func getLambda(eq equation) (float64, error) {
 		maximum = max(Abs(firstDerivative(i)), maximum)
 		i += eq.accuracy / 10
 	}
+	if maximum <= 0 {
+		return 0, SimpleIterationError{"Невозможно использовать метод простых итераций: Первая производная равна 0"}
+	}
 	if isPositive {
 		return -1 / maximum, nil
 	}
 	return 1 / maximum, nil
 }
 
-// Получение новой функции умножением ее на коэффициент
-func getNewFunction(eq equation, lambda float64) equation {
-	newEq := eq
-	newEq.koeff = make([]float64, len(eq.koeff))
-	for index, number := range eq.koeff {
-		newEq.koeff[index] = number * lambda
-	}
-	return newEq
-}
-
 // Взять данные для уравнения с консоли
 func getInfoFromConsole(in *bufio.Reader, eq *equation) {
 	eq.a, eq.b = getBorder(in)
@@ -314,11 +274,16 @@ func getInfoFromFile(in *bufio.Reader, eq *equation) {
 
 // Запуск программы по решению нелинейных уравнений
 func LinearEquation(in *bufio.Reader, out *bufio.Writer) {
-	koeff, err := getKoeff(in)
+	expr, err := getFunction(in)
 	if err != nil {
 		GetOut(err)
 	}
-	var eq = equation{koeff, false, 0, 0, 0, 1000000}
+	derivative := Derivative(expr)
+	secondDerivative := Derivative(derivative)
+	fmt.Println("f(x) =", expr)
+	fmt.Println("f'(x) =", derivative)
+	fmt.Println("f''(x) =", secondDerivative)
+	var eq = equation{expr, derivative, secondDerivative, Compile(expr), false, 0, 0, 0, 1000000}
 	fmt.Print("Выберете, как ввести данные\n 1) Файл\n 2) Вручную\n Enter: ")
 	var option int
 	ReadInt(in, &option, true)
@@ -337,33 +302,57 @@ func LinearEquation(in *bufio.Reader, out *bufio.Writer) {
 	DrawSingleFunction(getSourceFunction(eq), eq.a-(eq.b-eq.a)/10, eq.b+(eq.b-eq.a)/10, eq.accuracy)
 	f := getSourceFunction(eq)
 
-	answer, itera, err := methodChord(eq)
-	if err != nil {
-		GetOut(err)
+	var results []methodResult
+	for _, m := range rootMethods {
+		answer, itera, err := m.solve(eq)
+		results = append(results, methodResult{m.name, answer, itera, err})
 	}
+	// ответ дает метод Брента, остальные методы приводятся только для сравнения
+	answer := results[0]
 	fmt.Fprintln(out, "")
-	fmt.Fprintf(out, "Корень, полученный методом хорд: %.4f\n", answer)
-	fmt.Fprintf(out, "Значение функции в данной точке: %.4f\n", f(answer))
-	fmt.Fprintln(out, "Количество итераций: ", itera)
-	fmt.Fprintln(out, "")
-
-	anotherAnswer, itera, err := methodNewton(eq)
-	if err != nil {
-		GetOut(err)
+	if answer.err != nil {
+		fmt.Fprintf(out, "Метод %s: %v\n", answer.name, answer.err)
+	} else {
+		fmt.Fprintf(out, "Корень, полученный методом %s: %.4f\n", answer.name, answer.answer)
+		fmt.Fprintf(out, "Значение функции в данной точке: %.4f\n", f(answer.answer))
+		fmt.Fprintln(out, "Количество итераций: ", answer.itera)
 	}
 	fmt.Fprintln(out, "")
-	fmt.Fprintf(out, "Корень, полученный методом Ньютона: %.4f\n", anotherAnswer)
-	fmt.Fprintf(out, "Значение функции в данной точке: %.4f\n", f(anotherAnswer))
-	fmt.Fprintln(out, "Количество итераций: ", itera)
-	fmt.Fprintln(out, "")
+	printComparison(out, f, results)
+}
 
-	aAnotherAnswer, itera, err := methodSimpleItaration(eq)
-	if err != nil {
-		GetOut(err)
+// Методы поиска корня: первый дает ответ, все вместе - сравнительную таблицу
+var rootMethods = []struct {
+	name  string
+	solve func(eq equation) (float64, int, error)
+}{
+	{"Брента", methodBrent},
+	{"хорд", methodChord},
+	{"Ньютона", methodNewton},
+	{"простых итераций", methodSimpleItaration},
+	{"половинного деления", methodBisection},
+	{"секущих", methodSecant},
+	{"Иллинойс", methodIllinois},
+	{"Пегаса", methodPegasus},
+}
+
+// Результат одного метода для сравнительной таблицы
+type methodResult struct {
+	name   string
+	answer float64
+	itera  int
+	err    error
+}
+
+// Вывод сравнительной таблицы методов
+func printComparison(out *bufio.Writer, f func(x float64) float64, results []methodResult) {
+	fmt.Fprintln(out, "Сравнение методов")
+	fmt.Fprintf(out, "%-20s %12s %12s %10s\n", "Метод", "Корень", "f(корня)", "Итерации")
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(out, "%-20s %s\n", r.name, r.err)
+			continue
+		}
+		fmt.Fprintf(out, "%-20s %12.6f %12.2e %10d\n", r.name, r.answer, f(r.answer), r.itera)
 	}
-	fmt.Fprintln(out, "")
-	fmt.Fprintf(out, "Корень, полученный методом простых итераций: %.4f\n", aAnotherAnswer)
-	fmt.Fprintf(out, "Значение функции в данной точке: %.4f\n", f(anotherAnswer))
-	fmt.Fprintln(out, "Количество итераций: ", itera)
-	fmt.Fprintln(out, "")
 }
@@ -0,0 +1,158 @@
+package main
+
+import "math"
+
+// Метод половинного деления
+func methodBisection(eq equation) (float64, int, error) {
+	f := getSourceFunction(eq)
+	a, b := eq.a, eq.b
+	fa := f(a)
+	var x = (a + b) / 2
+	var k = 0
+	for Abs(b-a) >= 2*eq.accuracy && k < eq.itera {
+		fx := f(x)
+		if fx == 0 {
+			break
+		}
+		if fa*fx < 0 {
+			b = x
+		} else {
+			a, fa = x, fx
+		}
+		x = (a + b) / 2
+		k++
+	}
+	if k == eq.itera {
+		return 0, 0, IterationError{}
+	}
+	return x, k, nil
+}
+
+// Метод секущих, начальные приближения - границы интервала
+func methodSecant(eq equation) (float64, int, error) {
+	f := getSourceFunction(eq)
+	x0, x1 := eq.a, eq.b
+	f0, f1 := f(x0), f(x1)
+	var k = 0
+	for (Abs(x1-x0) >= eq.accuracy || Abs(f1) >= eq.accuracy) && k < eq.itera {
+		if f1 == f0 {
+			return 0, 0, ZeroDivisionError{"секущих"}
+		}
+		x0, x1 = x1, x1-f1*(x1-x0)/(f1-f0)
+		f0, f1 = f1, f(x1)
+		k++
+	}
+	if k == eq.itera {
+		return 0, 0, IterationError{}
+	}
+	return x1, k, nil
+}
+
+// Модифицированный метод хорд: если граница остается на месте, значение функции
+// в ней уменьшается, чтобы хорда не застаивалась как при фиксированной границе.
+// Иллинойс делит значение пополам, Пегас умножает на fb/(fb+fx)
+func methodRegulaFalsi(eq equation, pegasus bool) (float64, int, error) {
+	f := getSourceFunction(eq)
+	a, b := eq.a, eq.b
+	fa, fb := f(a), f(b)
+	var x = b
+	var k = 0
+	for k < eq.itera {
+		x = b - fb*(b-a)/(fb-fa)
+		fx := f(x)
+		k++
+		if fx*fb < 0 {
+			a, fa = b, fb
+		} else if pegasus {
+			fa *= fb / (fb + fx)
+		} else {
+			fa /= 2
+		}
+		b, fb = x, fx
+		if Abs(fx) < eq.accuracy || Abs(b-a) < eq.accuracy {
+			break
+		}
+	}
+	if k == eq.itera {
+		return 0, 0, IterationError{}
+	}
+	return x, k, nil
+}
+
+// Метод Иллинойс
+func methodIllinois(eq equation) (float64, int, error) {
+	return methodRegulaFalsi(eq, false)
+}
+
+// Метод Пегаса
+func methodPegasus(eq equation) (float64, int, error) {
+	return methodRegulaFalsi(eq, true)
+}
+
+// Метод Брента: обратная квадратичная интерполяция и секущие с откатом
+// к половинному делению, если шаг интерполяции ненадежен
+func methodBrent(eq equation) (float64, int, error) {
+	f := getSourceFunction(eq)
+	a, b := eq.a, eq.b
+	fa, fb := f(a), f(b)
+	c, fc := a, fa
+	d := b - a
+	e := d
+	// k - число уже сделанных шагов, как в остальных методах
+	for k := 0; k < eq.itera; k++ {
+		if fb*fc > 0 {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if Abs(fc) < Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2*(math.Nextafter(1, 2)-1)*Abs(b) + eq.accuracy/2
+		m := (c - b) / 2
+		if Abs(m) <= tol || Abs(fb) < eq.accuracy {
+			return b, k, nil
+		}
+		if Abs(e) >= tol && Abs(fa) > Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// секущая
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// обратная квадратичная интерполяция
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < min(3*m*q-Abs(tol*q), Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = m
+			}
+		} else {
+			d = m
+			e = m
+		}
+		a, fa = b, fb
+		if Abs(d) > tol {
+			b += d
+		} else if m > 0 {
+			b += tol
+		} else {
+			b -= tol
+		}
+		fb = f(b)
+	}
+	return 0, 0, IterationError{}
+}
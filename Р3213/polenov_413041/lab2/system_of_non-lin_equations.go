@@ -12,6 +12,7 @@ type equationExtend struct {
 	funcs       []function
 	derivativeX []function
 	derivativeY []function
+	system      systemFunction[Dual]
 	x           float64
 	y           float64
 	xPlace      []float64
@@ -20,58 +21,46 @@ type equationExtend struct {
 	vectorError []float64
 }
 
-// Получение первой системы уравнений
-func getFirstSystem(eq *equationExtend) {
-	eq.funcs = make([]function, 2)
-	eq.funcs[0] = func(x float64, y float64) float64 {
-		return 0.3 - 0.1*x*x - 0.2*y*y
-	}
-	eq.funcs[1] = func(x float64, y float64) float64 {
-		return 0.7 - 0.2*x*x - 0.1*x*y
+// Первая система в виде x = ϕ1(x, y), y = ϕ2(x, y)
+func firstSystem[T autodiff[T]](x, y T) []T {
+	return []T{
+		x.Mul(x).Scale(-0.1).Sub(y.Mul(y).Scale(0.2)).Shift(0.3),
+		x.Mul(x).Scale(-0.2).Sub(x.Mul(y).Scale(0.1)).Shift(0.7),
 	}
+}
 
-	eq.derivativeX = make([]function, 2)
-	eq.derivativeX[0] = func(x float64, y float64) float64 {
-		return -0.2 * x
-	}
-	eq.derivativeX[1] = func(x float64, y float64) float64 {
-		return -0.4*x - 0.1*y
+// Вторая система в виде x = ϕ1(x, y), y = ϕ2(x, y)
+func secondSystem[T autodiff[T]](x, y T) []T {
+	return []T{
+		y.Shift(1).Sin().Shift(1),
+		x.Shift(-1).Sin().Neg().Shift(1.5),
 	}
+}
 
-	eq.derivativeY = make([]function, 2)
-	eq.derivativeY[0] = func(x float64, y float64) float64 {
-		return -0.4 * y
-	}
-	eq.derivativeY[1] = func(x float64, y float64) float64 {
-		return -0.1 * x
+// Заполнение функций системы и их частных производных по одному определению
+func setSystem(eq *equationExtend, system systemFunction[Dual]) {
+	eq.system = system
+	n := len(system(Dual{}, Dual{}))
+	eq.funcs = make([]function, n)
+	eq.derivativeX = make([]function, n)
+	eq.derivativeY = make([]function, n)
+	for i := 0; i < n; i++ {
+		eq.funcs[i] = func(x float64, y float64) float64 {
+			return system(Dual{x, 0}, Dual{y, 0})[i].Re
+		}
+		eq.derivativeX[i] = partialDerivative(system, i, true)
+		eq.derivativeY[i] = partialDerivative(system, i, false)
 	}
 }
 
+// Получение первой системы уравнений
+func getFirstSystem(eq *equationExtend) {
+	setSystem(eq, firstSystem[Dual])
+}
+
 // Получение второй системы уравнений
 func getSecondSystem(eq *equationExtend) {
-	eq.funcs = make([]function, 2)
-	eq.funcs[0] = func(x float64, y float64) float64 {
-		return 1 + math.Sin(y+1)
-	}
-	eq.funcs[1] = func(x float64, y float64) float64 {
-		return 1.5 - math.Sin(x-1)
-	}
-
-	eq.derivativeX = make([]function, 2)
-	eq.derivativeX[0] = func(x float64, y float64) float64 {
-		return 0
-	}
-	eq.derivativeX[1] = func(x float64, y float64) float64 {
-		return math.Cos(x - 1)
-	}
-
-	eq.derivativeY = make([]function, 2)
-	eq.derivativeY[0] = func(x float64, y float64) float64 {
-		return math.Cos(y + 1)
-	}
-	eq.derivativeY[1] = func(x float64, y float64) float64 {
-		return 0
-	}
+	setSystem(eq, secondSystem[Dual])
 }
 
 // Получение области изоляции корня, начальное приближение и точность
@@ -99,12 +88,20 @@ func getJunkInfo(in *bufio.Reader, eq *equationExtend) {
 	eq.accuracy = epsilon
 }
 
-// Проверка правильности использования метода простых итераций для первой системы уравнений
+// Проверка правильности использования метода простых итераций: норма матрицы
+// Якоби отображения ϕ меньше единицы во всей области изоляции
 func checkSystem(eq equationExtend) bool {
-	var x, y = MaxAbs(eq.xPlace[0], eq.xPlace[1]), MaxAbs(eq.yPlace[0], eq.yPlace[1])
-	for i := 0; i < 2; i++ {
-		if Abs(eq.derivativeX[i](x, y))+Abs(eq.derivativeY[i](x, y)) >= 1 {
-			return false
+	const steps = 20
+	hx := (eq.xPlace[1] - eq.xPlace[0]) / steps
+	hy := (eq.yPlace[1] - eq.yPlace[0]) / steps
+	for i := 0; i <= steps; i++ {
+		for j := 0; j <= steps; j++ {
+			_, jacobian := jacobianAt(eq.system, eq.xPlace[0]+hx*float64(i), eq.yPlace[0]+hy*float64(j))
+			for _, row := range jacobian {
+				if Abs(row[0])+Abs(row[1]) >= 1 {
+					return false
+				}
+			}
 		}
 	}
 	return true
@@ -130,6 +127,31 @@ func solveSystem(eq *equationExtend, M int) int {
 	return k
 }
 
+// Решение системы уравнений методом Ньютона для F(x, y) = ϕ(x, y) - (x, y) = 0
+func solveSystemNewton(eq *equationExtend, M int) (int, error) {
+	eq.vectorError = make([]float64, 2)
+	for k := 1; k <= M; k++ {
+		phi, jacobian := jacobianAt(eq.system, eq.x, eq.y)
+		f1, f2 := phi[0]-eq.x, phi[1]-eq.y
+		a, b := jacobian[0][0]-1, jacobian[0][1]
+		c, d := jacobian[1][0], jacobian[1][1]-1
+		det := a*d - b*c
+		if math.Abs(det) < 1e-14 {
+			return k, JacobianError{}
+		}
+		// решение J*Δ = -F по формулам Крамера
+		dx := (-f1*d + f2*b) / det
+		dy := (-f2*a + f1*c) / det
+		eq.x += dx
+		eq.y += dy
+		eq.vectorError[0], eq.vectorError[1] = Abs(dx), Abs(dy)
+		if max(eq.vectorError[0], eq.vectorError[1]) < eq.accuracy {
+			return k, nil
+		}
+	}
+	return M, IterationError{}
+}
+
 // LinearSystem Запуск программы по решению системы нелинейных уравнений
 func LinearSystem(in *bufio.Reader, out *bufio.Writer) {
 	var eq equationExtend
@@ -155,9 +177,22 @@ func LinearSystem(in *bufio.Reader, out *bufio.Writer) {
 		GetOut(err)
 	}
 
+	var newtonEq = eq
 	var iterations = solveSystem(&eq, 1000000)
 
+	fmt.Fprintln(out, "Метод простых итераций")
 	fmt.Fprintln(out, "Вектор неизвестных: ", eq.x, eq.y)
 	fmt.Fprintln(out, "Количество итераций: ", iterations)
 	fmt.Fprintln(out, "Вектор погрешностей: ", eq.vectorError)
+
+	iterations, err = solveSystemNewton(&newtonEq, 1000000)
+	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, "Метод Ньютона")
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	fmt.Fprintln(out, "Вектор неизвестных: ", newtonEq.x, newtonEq.y)
+	fmt.Fprintln(out, "Количество итераций: ", iterations)
+	fmt.Fprintln(out, "Вектор погрешностей: ", newtonEq.vectorError)
 }